@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect, change and repair the squirrel config",
+	Long: `Settings are addressed by dotted keys:
+
+  syncDir, host                  top-level settings
+  defaults.<flag>                default for a flag of any command
+  commands.<command>.<flag>      default for one command only
+
+Flag values are taken from the command line first, then from SQUIRREL_<FLAG>
+environment variables (e.g. SQUIRREL_DAYS=30), then from the command's
+section, then from defaults.
+
+  squirrel config set defaults.days 30
+  squirrel config set commands.project.depth deep
+  squirrel config set defaults.days ""        # remove the setting`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := squirrel.ParseConfigKey(args[0])
+		if err != nil {
+			return err
+		}
+		cfg, err := squirrel.LoadConfig(configPath())
+		if err != nil {
+			return err
+		}
+		value, ok := cfg.Get(k)
+		if !ok {
+			return fmt.Errorf("%s is not set", args[0])
+		}
+		fmt.Println(value)
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting (an empty value removes it)",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := squirrel.ParseConfigKey(args[0])
+		if err != nil {
+			return err
+		}
+		value := args[1]
+		if k.Flag != "" && value != "" {
+			f, err := lookupFlag(k)
+			if err != nil {
+				return err
+			}
+			if err := validateFlagValue(f, value); err != nil {
+				return fmt.Errorf("--%s: %w", f.Name, err)
+			}
+		}
+		if k.Name == "syncDir" && value != "" {
+			if value, err = filepath.Abs(value); err != nil {
+				return err
+			}
+		}
+
+		err = squirrel.UpdateConfig(configPath(), func(cfg *squirrel.Config) error {
+			cfg.Set(k, value)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			fmt.Printf("Removed %s\n", args[0])
+		} else {
+			fmt.Printf("%s = %s\n", args[0], value)
+		}
+		return nil
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List settings and flag defaults",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := squirrel.LoadConfig(configPath())
+		if err != nil {
+			return err
+		}
+		for _, s := range cfg.Settings() {
+			fmt.Printf("%s = %s\n", s.Key, s.Value)
+		}
+		return nil
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the config file location",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(configPath())
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config in $VISUAL or $EDITOR and validate it before saving",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := configPath()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("creating config directory: %w", err)
+		}
+
+		before, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("reading config: %w", err)
+		}
+		current := before
+		if len(current) == 0 {
+			current = []byte("{}\n")
+		}
+
+		tmp, err := os.CreateTemp(filepath.Dir(path), "config-edit-*.json")
+		if err != nil {
+			return err
+		}
+		tmp.Close()
+		if err := os.WriteFile(tmp.Name(), current, 0644); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+
+		editor := os.Getenv("VISUAL")
+		if editor == "" {
+			editor = os.Getenv("EDITOR")
+		}
+		if editor == "" {
+			editor = "vi"
+		}
+		// Run through the shell so editors with arguments ("code --wait") work.
+		edit := exec.Command("sh", "-c", editor+` "$1"`, "sh", tmp.Name())
+		edit.Stdin, edit.Stdout, edit.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := edit.Run(); err != nil {
+			os.Remove(tmp.Name())
+			return fmt.Errorf("running editor: %w", err)
+		}
+
+		edited, err := squirrel.LoadConfig(tmp.Name())
+		if err == nil {
+			err = validateSettings(edited)
+		}
+		if err != nil {
+			return fmt.Errorf("changes not saved, your edit is kept in %s: %w", tmp.Name(), err)
+		}
+
+		err = squirrel.UpdateConfig(path, func(cfg *squirrel.Config) error {
+			// An ack, note or sync written meanwhile would be lost by saving
+			// the edited copy over it
+			now, err := os.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("reading config: %w", err)
+			}
+			if !bytes.Equal(now, before) {
+				return fmt.Errorf("changes not saved, the config was changed while you were editing; your edit is kept in %s, run squirrel config edit again to redo it", tmp.Name())
+			}
+			cfg.Reset(edited)
+			return nil
+		})
+		if err == nil {
+			os.Remove(tmp.Name())
+		}
+		return err
+	},
+}
+
+// validateSettings checks every flag default in cfg against the known flags.
+func validateSettings(cfg *squirrel.Config) error {
+	for _, s := range cfg.Settings() {
+		k, err := squirrel.ParseConfigKey(s.Key)
+		if err != nil || k.Flag == "" {
+			continue
+		}
+		f, err := lookupFlag(k)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.Key, err)
+		}
+		if err := validateFlagValue(f, s.Value); err != nil {
+			return fmt.Errorf("%s: %w", s.Key, err)
+		}
+	}
+	return nil
+}
+
+var configRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore the config from the backup taken before the last write",
+	Long: `Every write keeps the previous config next to it as config.json.bak.
+Restore puts that version back, e.g. after the config got corrupted.
+The replaced file is kept as config.json.corrupt.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := configPath()
+		if err := squirrel.RestoreConfig(path); err != nil {
+			return err
+		}
+		fmt.Printf("Restored %s from %s\n", path, squirrel.ConfigBackupPath(path))
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configPathCmd)
+	configCmd.AddCommand(configRestoreCmd)
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var configFile string
+
+// configPath returns the config file selected by --config, SQUIRREL_CONFIG
+// or the default location.
+func configPath() string {
+	if configFile != "" {
+		return configFile
+	}
+	if env := os.Getenv("SQUIRREL_CONFIG"); env != "" {
+		return env
+	}
+	return squirrel.DefaultConfigPath()
+}
+
+// defaultFlags are the flags filled in from the config and the environment:
+// settings that shape the analysis and its output. Flags that select what a
+// command acts on, like --pattern, --html or --dir, never take defaults.
+var defaultFlags = map[string]bool{
+	"depth": true, "days": true, "since": true, "until": true,
+	"json": true, "format": true, "template": true, "fields": true,
+	"all-hosts": true, "tag": true, "group": true, "include-ignored": true,
+	"claude-dir": true, "color": true, "lang": true,
+	"by": true, "for": true, "wake-on": true, "weeks": true,
+	"addr": true, "refresh": true,
+}
+
+// flagValidators check values beyond what the flag type enforces.
+var flagValidators = map[string]func(string) error{
+	"depth": func(v string) error {
+		switch v {
+		case "quick", "medium", "deep":
+			return nil
+		}
+		return fmt.Errorf("invalid depth %q (use quick, medium or deep)", v)
+	},
+	"for": func(v string) error {
+		_, err := squirrel.ParseDuration(v)
+		return err
+	},
+	"wake-on": func(v string) error {
+		return squirrel.ValidateWakeOn(strings.Split(v, ","))
+	},
+	"by": func(v string) error {
+		if !slices.Contains(squirrel.TimelinePeriods, v) {
+			return fmt.Errorf("invalid period %q (use %s)", v, strings.Join(squirrel.TimelinePeriods, ", "))
+		}
+		return nil
+	},
+	"color": func(v string) error {
+		if !slices.Contains(squirrel.ColorModes, v) {
+			return fmt.Errorf("invalid color mode %q (use %s)", v, strings.Join(squirrel.ColorModes, ", "))
+		}
+		return nil
+	},
+	"since": func(v string) error {
+		_, err := squirrel.ParseRange(v, "", 0, clock())
+		return err
+	},
+	"until": func(v string) error {
+		// Any positive number of days will do; only the end is checked
+		_, err := squirrel.ParseRange("", v, 1, clock())
+		return err
+	},
+	"lang": func(v string) error {
+		_, err := squirrel.NewRenderer(squirrel.WithLanguage(v))
+		return err
+	},
+}
+
+// commandKey names cmd in the config's commands section. The bare root
+// command runs status, so it shares status' settings.
+func commandKey(cmd *cobra.Command) string {
+	key := strings.TrimPrefix(strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()), " ")
+	if key == "" {
+		return "status"
+	}
+	return key
+}
+
+// envName returns the environment variable that overrides flag.
+func envName(flag string) string {
+	return "SQUIRREL_" + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// applyDefaults fills in flags not given on the command line. Precedence is
+// command line, then SQUIRREL_* environment variables, then the command's
+// section in the config, then the config's defaults section.
+func applyDefaults(cmd *cobra.Command, args []string) error {
+	// The config commands must keep working on a broken config.
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == configCmd {
+			return nil
+		}
+	}
+
+	cfg, err := squirrel.LoadConfig(configPath())
+	if err != nil {
+		return err
+	}
+
+	command := commandKey(cmd)
+	var applyErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if applyErr != nil || f.Changed || !defaultFlags[f.Name] {
+			return
+		}
+		value, ok := os.LookupEnv(envName(f.Name))
+		source := envName(f.Name)
+		if !ok {
+			value, ok = cfg.FlagDefault(command, f.Name)
+			source = "config"
+		}
+		if !ok {
+			return
+		}
+		if err := validateFlagValue(f, value); err != nil {
+			applyErr = fmt.Errorf("%s: --%s: %w", source, f.Name, err)
+			return
+		}
+		if err := f.Value.Set(value); err != nil {
+			applyErr = fmt.Errorf("%s: --%s: %w", source, f.Name, err)
+		}
+	})
+	return applyErr
+}
+
+// validateFlagValue checks that value is acceptable for f without setting it.
+func validateFlagValue(f *pflag.Flag, value string) error {
+	switch f.Value.Type() {
+	case "int":
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+	case "bool":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+	}
+	if validate, ok := flagValidators[f.Name]; ok && value != "" {
+		return validate(value)
+	}
+	return nil
+}
+
+// lookupFlag finds the flag a settings key refers to. Global defaults may
+// name a flag of any command; only defaultFlags are accepted.
+func lookupFlag(k squirrel.ConfigKey) (*pflag.Flag, error) {
+	f, err := findFlag(k)
+	if err != nil {
+		return nil, err
+	}
+	if !defaultFlags[f.Name] {
+		return nil, fmt.Errorf("--%s cannot have a default", f.Name)
+	}
+	return f, nil
+}
+
+func findFlag(k squirrel.ConfigKey) (*pflag.Flag, error) {
+	if k.Command != "" {
+		cmd, rest, err := rootCmd.Find(strings.Fields(k.Command))
+		if err != nil || len(rest) > 0 || cmd == rootCmd {
+			return nil, fmt.Errorf("unknown command %q", k.Command)
+		}
+		if f := cmd.Flags().Lookup(k.Flag); f != nil {
+			return f, nil
+		}
+		if f := cmd.InheritedFlags().Lookup(k.Flag); f != nil {
+			return f, nil
+		}
+		return nil, fmt.Errorf("command %q has no flag --%s", k.Command, k.Flag)
+	}
+
+	var found *pflag.Flag
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		if found != nil {
+			return
+		}
+		if f := c.Flags().Lookup(k.Flag); f != nil {
+			found = f
+			return
+		}
+		if f := c.PersistentFlags().Lookup(k.Flag); f != nil {
+			found = f
+			return
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(rootCmd)
+	if found == nil {
+		return nil, fmt.Errorf("unknown flag --%s", k.Flag)
+	}
+	return found, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel/fixture"
+)
+
+var fixtureOpts fixture.Options
+
+var devCmd = &cobra.Command{
+	Use:    "dev",
+	Short:  "Tools for developing squirrel",
+	Hidden: true,
+}
+
+var genFixtureCmd = &cobra.Command{
+	Use:   "gen-fixture <dir>",
+	Short: "Generate a synthetic Claude data directory and git repositories",
+	Long: `Generate a Claude data directory with history.jsonl, session indexes and
+session files in <dir>/claude, for trying out and profiling squirrel without
+real data. With --repos every project also gets a git repository under
+<dir>/repos, in turn clean, dirty, on a feature branch, dirty on a feature
+branch, no repository or missing.
+
+  squirrel dev gen-fixture /tmp/fx --projects 200 --prompts 500 --repos
+  squirrel --claude-dir /tmp/fx/claude status --days 30`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := filepath.Abs(args[0])
+		if err != nil {
+			return err
+		}
+		opts := fixtureOpts
+		opts.Now = clock()
+		fx, err := fixture.Generate(dir, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Generated %d projects in %s\n", len(fx.Projects), fx.ClaudeDir)
+		fmt.Printf("Try: squirrel --claude-dir %s status --days %d\n", fx.ClaudeDir, max(opts.Days, fixture.DefaultOptions.Days))
+		return nil
+	},
+}
+
+func init() {
+	d := fixture.DefaultOptions
+	f := genFixtureCmd.Flags()
+	f.IntVar(&fixtureOpts.Projects, "projects", d.Projects, "Number of projects")
+	f.IntVar(&fixtureOpts.Prompts, "prompts", d.Prompts, "History prompts per project")
+	f.IntVar(&fixtureOpts.Sessions, "sessions", d.Sessions, "Sessions per project")
+	f.IntVar(&fixtureOpts.Messages, "messages", d.Messages, "Messages per session")
+	f.IntVar(&fixtureOpts.Days, "span", d.Days, "Days the history reaches back")
+	f.BoolVar(&fixtureOpts.Repos, "repos", false, "Create a git repository for each project")
+	f.Uint64Var(&fixtureOpts.Seed, "seed", d.Seed, "Seed for the generated content")
+
+	devCmd.AddCommand(genFixtureCmd)
+	rootCmd.AddCommand(devCmd)
+}
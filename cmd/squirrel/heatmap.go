@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var heatmapWeeks int
+
+var heatmapCmd = &cobra.Command{
+	Use:   "heatmap [project]",
+	Short: "Show a GitHub-style heatmap of prompts per day",
+	Long: `Show prompts per day as a contribution grid, one column per week, for all
+projects or a single one. The grid shrinks to the terminal width and falls
+back to ASCII characters when colour is unavailable.
+
+  squirrel heatmap
+  squirrel heatmap myapp --weeks 12`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if f, err := outputFormat(); err != nil {
+			return err
+		} else if f != "terminal" {
+			return fmt.Errorf("heatmap only supports terminal output")
+		}
+		if heatmapWeeks < 1 {
+			return fmt.Errorf("--weeks must be at least 1")
+		}
+
+		s, err := loadSquirrel()
+		if err != nil {
+			return err
+		}
+		var query string
+		if len(args) == 1 {
+			query = args[0]
+		}
+		counts, project, err := s.DailyActivity(query)
+		if err != nil {
+			return err
+		}
+
+		var name string
+		if query != "" {
+			name = project.ShortName
+		}
+		fmt.Print(renderer.RenderHeatmap(counts, window.Until.Add(-time.Nanosecond), heatmapWeeks, name))
+		return nil
+	},
+}
+
+func init() {
+	heatmapCmd.Flags().IntVar(&heatmapWeeks, "weeks", 52, "Number of weeks to show (reduced to fit the terminal)")
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+// ignoreEntry turns a command argument into an ignore list entry. Paths and
+// globs are used as given; anything else is looked up as a project name.
+func ignoreEntry(cfg *squirrel.Config, arg string) (string, error) {
+	if squirrel.IsGlob(arg) || strings.HasPrefix(arg, "~") {
+		return arg, nil
+	}
+	if filepath.IsAbs(arg) || strings.HasPrefix(arg, ".") {
+		return filepath.Abs(arg)
+	}
+	project, err := resolveProject(cfg, arg)
+	if err != nil {
+		return "", err
+	}
+	return project.Path, nil
+}
+
+var ignoreCmd = &cobra.Command{
+	Use:   "ignore [path|pattern|project]",
+	Short: "Exclude a path, path glob or project from all reports",
+	Long: `Exclude projects from all reports. Unlike ack, ignored projects do not show
+up anywhere, unless --include-ignored is given.
+
+A path ignores exactly that project directory; a glob (* within a
+directory, ** across directories) ignores all matching project paths:
+
+  squirrel ignore ~
+  squirrel ignore '/tmp/**'
+  squirrel ignore '~/src/vendor-*'
+  squirrel ignore myapp`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var entry string
+		added := false
+		err := squirrel.UpdateConfig(configPath(), func(cfg *squirrel.Config) error {
+			var err error
+			if entry, err = ignoreEntry(cfg, args[0]); err != nil {
+				return err
+			}
+			if added = cfg.AddIgnore(entry); !added {
+				return squirrel.ErrUnchanged
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if !added {
+			fmt.Printf("%s is already ignored\n", entry)
+			return nil
+		}
+		fmt.Printf("Ignoring %s\n", entry)
+		return nil
+	},
+}
+
+var unignoreCmd = &cobra.Command{
+	Use:   "unignore [path|pattern|project]",
+	Short: "Remove an entry from the ignore list",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		removed := false
+		err := squirrel.UpdateConfig(configPath(), func(cfg *squirrel.Config) error {
+			removed = cfg.RemoveIgnore(args[0])
+			if !removed {
+				if entry, err := ignoreEntry(cfg, args[0]); err == nil {
+					removed = cfg.RemoveIgnore(entry)
+				}
+			}
+			if !removed {
+				return squirrel.ErrUnchanged
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if !removed {
+			fmt.Printf("%s is not on the ignore list\n", args[0])
+			return nil
+		}
+		fmt.Printf("No longer ignoring %s\n", args[0])
+		return nil
+	},
+}
+
+var ignoredCmd = &cobra.Command{
+	Use:   "ignored",
+	Short: "List the ignore list and the projects it hides",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := squirrel.LoadConfig(configPath())
+		if err != nil {
+			return err
+		}
+		if len(cfg.Ignore()) == 0 {
+			fmt.Println("Nothing ignored")
+			return nil
+		}
+
+		s, err := newSquirrel(cfg)
+		if err != nil {
+			return err
+		}
+		ignored, err := s.Ignored()
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range cfg.Ignore() {
+			var hidden []string
+			for _, p := range ignored {
+				if p.IgnoredBy == entry {
+					hidden = append(hidden, p.ShortName)
+				}
+			}
+			fmt.Printf("%s  (%d projects from %s to %s)\n", entry, len(hidden), window.Since.Format("2006-01-02"), window.Until.Add(-time.Nanosecond).Format("2006-01-02"))
+			for _, name := range hidden {
+				fmt.Printf("  - %s\n", name)
+			}
+		}
+		return nil
+	},
+}
@@ -4,94 +4,187 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
-	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
-	"github.com/dkd-dobberkau/squirrel/internal/claude"
-	"github.com/dkd-dobberkau/squirrel/internal/config"
-	"github.com/dkd-dobberkau/squirrel/internal/output"
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
 )
 
 var version = "dev"
 
 var (
-	depth       string
-	days        int
-	jsonOut     bool
-	forDuration string
+	depth        string
+	days         int
+	sinceExpr    string
+	untilExpr    string
+	jsonOut      bool
+	format       string
+	templatePath string
+	fields       []string
+	forDuration  string
+	allHosts     bool
+	tagFilter    []string
+	groupFilter  []string
+
+	ackPattern    string
+	ackRegex      bool
+	ackNotGit     bool
+	ackMaxPrompts int
+	ackHomeRoot   bool
+	ackList       bool
+	wakeOn        []string
+	unackPattern  string
+
+	includeIgnored bool
+
+	lang string
+
+	timelineBy string
+
+	claudeDirFlag []string
 )
 
-func claudeDir() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".claude")
+// window is the time range selected by --since, --until and --days,
+// resolved before any command runs.
+var window squirrel.Range
+
+// clock and gitRunner are the time source and git runner used by all
+// commands; tests replace them. A nil gitRunner runs the git binary.
+var (
+	clock     = time.Now
+	gitRunner squirrel.GitRunner
+)
+
+// newSquirrel returns a Squirrel for cfg set up from the command line.
+func newSquirrel(cfg *squirrel.Config) (*squirrel.Squirrel, error) {
+	return squirrel.New(squirrelOptions(cfg)...)
 }
 
-func runAnalysis() (analyzer.CategorizedProjects, error) {
-	cDir := claudeDir()
-	histPath := filepath.Join(cDir, "history.jsonl")
+// squirrelOptions are the options for cfg selected on the command line.
+func squirrelOptions(cfg *squirrel.Config) []squirrel.Option {
+	return []squirrel.Option{
+		squirrel.WithConfig(cfg),
+		squirrel.WithClaudeDirs(claudeDirFlag...),
+		squirrel.WithClock(clock),
+		squirrel.WithGit(gitRunner),
+		squirrel.WithDepth(squirrel.Depth(depth)),
+		squirrel.WithRange(window),
+		squirrel.WithAllHosts(allHosts),
+		squirrel.WithIgnored(includeIgnored),
+		squirrel.WithTags(tagFilter...),
+		squirrel.WithGroups(groupFilter...),
+	}
+}
 
-	entries, err := claude.ParseHistory(histPath)
+// loadSquirrel loads the config and returns a Squirrel for it.
+func loadSquirrel() (*squirrel.Squirrel, error) {
+	cfg, err := squirrel.LoadConfig(configPath())
 	if err != nil {
-		return analyzer.CategorizedProjects{}, fmt.Errorf("reading history: %w", err)
+		return nil, err
 	}
+	return newSquirrel(cfg)
+}
 
-	projects := claude.AggregateByProject(entries, days)
-
-	claude.EnrichWithSessions(projects, filepath.Join(cDir, "projects"))
-
-	if depth == "medium" || depth == "deep" {
-		analyzer.EnrichWithGit(projects)
+// resolveProject finds the project matching query for a command that
+// changes cfg.
+func resolveProject(cfg *squirrel.Config, query string) (squirrel.Project, error) {
+	s, err := newSquirrel(cfg)
+	if err != nil {
+		return squirrel.Project{}, err
 	}
+	return s.Resolve(query)
+}
 
-	// Load config for acknowledged projects
-	cfg, err := config.Load(config.DefaultPath())
+func runAnalysis() (squirrel.Result, error) {
+	s, err := loadSquirrel()
 	if err != nil {
-		cfg = &config.Config{} // gracefully continue without config
-	}
-	ackedPaths := make(map[string]bool)
-	for _, p := range projects {
-		if cfg.IsAcknowledged(p.Path) {
-			ackedPaths[p.Path] = true
-		}
+		return squirrel.Result{}, err
 	}
+	return s.Analyze()
+}
 
-	categorized := analyzer.Categorize(projects, ackedPaths)
+// formats lists the values accepted by --format; the built-in templates
+// are formats too.
+var formats = append([]string{"terminal", "json", "markdown", "csv", "tsv", "ndjson"}, squirrel.BuiltinTemplates...)
 
-	if depth == "deep" {
-		allProjects := append(categorized.OpenWork, categorized.RecentActivity...)
-		allProjects = append(allProjects, categorized.Sleeping...)
-		claude.EnrichAllWithTodos(allProjects, filepath.Join(cDir, "projects"))
-		// Write back enriched projects
-		idx := 0
-		for i := range categorized.OpenWork {
-			categorized.OpenWork[i] = allProjects[idx]
-			idx++
-		}
-		for i := range categorized.RecentActivity {
-			categorized.RecentActivity[i] = allProjects[idx]
-			idx++
-		}
-		for i := range categorized.Sleeping {
-			categorized.Sleeping[i] = allProjects[idx]
-			idx++
-		}
+// outputFormat returns the selected output format; --json is shorthand for
+// --format json and --template selects a user template.
+func outputFormat() (string, error) {
+	if templatePath != "" {
+		return "template", nil
+	}
+	if jsonOut {
+		return "json", nil
+	}
+	if !slices.Contains(formats, format) {
+		return "", fmt.Errorf("unknown format %q (use %s)", format, strings.Join(formats, ", "))
+	}
+	if len(fields) > 0 && !slices.Contains([]string{"csv", "tsv", "ndjson"}, format) {
+		return "", fmt.Errorf("--fields only applies to --format csv, tsv and ndjson")
 	}
+	if err := squirrel.ValidateFields(fields); err != nil {
+		return "", err
+	}
+	return format, nil
+}
 
-	return categorized, nil
+// separator returns the field separator for the delimited formats.
+func separator(f string) rune {
+	if f == "tsv" {
+		return '\t'
+	}
+	return ','
 }
 
-func renderOutput(data analyzer.CategorizedProjects) error {
-	if jsonOut {
-		s, err := output.RenderJSON(data)
+// outputTemplate returns the template for the template-based formats.
+func outputTemplate(f string) (*squirrel.Template, error) {
+	if f == "template" {
+		return squirrel.LoadTemplate(templatePath)
+	}
+	return squirrel.BuiltinTemplate(f)
+}
+
+func renderOutput(data squirrel.Result) error {
+	f, err := outputFormat()
+	if err != nil {
+		return err
+	}
+	switch f {
+	case "terminal":
+		fmt.Print(renderer.RenderTerminal(data))
+	case "json":
+		s, err := squirrel.RenderJSON(data)
 		if err != nil {
 			return err
 		}
 		fmt.Println(s)
-	} else {
-		fmt.Print(output.RenderTerminal(data))
+	case "markdown":
+		fmt.Print(renderer.RenderMarkdown(data))
+	case "csv", "tsv", "ndjson":
+		var s string
+		var err error
+		if f == "ndjson" {
+			s, err = squirrel.RenderNDJSON(data, fields)
+		} else {
+			s, err = squirrel.RenderCSV(data, fields, separator(f))
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Print(s)
+	default:
+		t, err := outputTemplate(f)
+		if err != nil {
+			return err
+		}
+		s, err := renderer.RenderTemplate(t, data, window.Until)
+		if err != nil {
+			return err
+		}
+		fmt.Print(s)
 	}
 	return nil
 }
@@ -139,17 +232,44 @@ var stashCmd = &cobra.Command{
 var timelineCmd = &cobra.Command{
 	Use:   "timeline",
 	Short: "Show chronological activity timeline",
+	Long: `Show which projects were active per day, week or month, newest first, with
+prompt counts and the sessions worked on in each period.
+
+  squirrel timeline --by week --days 60
+  squirrel timeline --since last-month --until last-month
+  squirrel timeline --by month --days 365 --json`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		data, err := runAnalysis()
+		f, err := outputFormat()
 		if err != nil {
 			return err
 		}
-		all := append(data.OpenWork, data.RecentActivity...)
-		all = append(all, data.Sleeping...)
-		data.OpenWork = nil
-		data.RecentActivity = all
-		data.Sleeping = nil
-		return renderOutput(data)
+		if !slices.Contains([]string{"terminal", "json", "markdown"}, f) {
+			return fmt.Errorf("timeline supports --format terminal, json and markdown")
+		}
+
+		s, err := loadSquirrel()
+		if err != nil {
+			return err
+		}
+		buckets, err := s.Timeline(timelineBy)
+		if err != nil {
+			return err
+		}
+
+		switch f {
+		case "json":
+			s, err := squirrel.RenderTimelineJSON(buckets, timelineBy)
+			if err != nil {
+				return err
+			}
+			fmt.Println(s)
+		case "markdown":
+			fmt.Print(renderer.RenderTimelineMarkdown(buckets, timelineBy))
+		default:
+			fmt.Print(renderer.RenderTimeline(buckets, timelineBy))
+		}
+		return nil
 	},
 }
 
@@ -159,49 +279,50 @@ var projectCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		resolveDepthShortcuts(cmd)
-		cDir := claudeDir()
-		histPath := filepath.Join(cDir, "history.jsonl")
-
-		entries, err := claude.ParseHistory(histPath)
+		s, err := loadSquirrel()
 		if err != nil {
-			return fmt.Errorf("reading history: %w", err)
-		}
-
-		// Use a wider window for detail view
-		projects := claude.AggregateByProject(entries, 365)
-		claude.EnrichWithSessions(projects, filepath.Join(cDir, "projects"))
-
-		if depth == "medium" || depth == "deep" {
-			analyzer.EnrichWithGit(projects)
-		}
-
-		// Score all projects
-		for i := range projects {
-			projects[i].Score = analyzer.Score(projects[i])
+			return err
 		}
-
-		project, ok := claude.FindProject(projects, args[0])
-		if !ok {
-			return fmt.Errorf("project %q not found", args[0])
+		detail, err := s.Project(args[0])
+		if err != nil {
+			return err
 		}
-
-		if depth == "deep" {
-			claude.EnrichWithTodos(&project, filepath.Join(cDir, "projects"))
+		f, err := outputFormat()
+		if err != nil {
+			return err
 		}
-
-		prompts := claude.PromptsForProject(entries, project.Path, 10)
-
-		if jsonOut {
-			s, err := output.RenderProjectDetailJSON(output.ProjectDetail{
-				Project:       project,
-				RecentPrompts: prompts,
-			})
+		switch f {
+		case "terminal":
+			fmt.Print(renderer.RenderProject(detail))
+		case "json":
+			s, err := squirrel.RenderProjectJSON(detail)
 			if err != nil {
 				return err
 			}
 			fmt.Println(s)
-		} else {
-			fmt.Print(output.RenderProjectDetail(project, prompts))
+		case "markdown":
+			fmt.Print(renderer.RenderProjectMarkdown(detail))
+		case "csv", "tsv", "ndjson":
+			var s string
+			if f == "ndjson" {
+				s, err = squirrel.RenderProjectNDJSON(detail, fields)
+			} else {
+				s, err = squirrel.RenderProjectCSV(detail, fields, separator(f))
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Print(s)
+		default:
+			t, err := outputTemplate(f)
+			if err != nil {
+				return err
+			}
+			s, err := renderer.RenderProjectTemplate(t, detail, window.Until)
+			if err != nil {
+				return err
+			}
+			fmt.Print(s)
 		}
 
 		return nil
@@ -211,81 +332,187 @@ var projectCmd = &cobra.Command{
 var ackCmd = &cobra.Command{
 	Use:   "ack [project]",
 	Short: "Acknowledge a project (moves it to the Acknowledged section)",
-	Args:  cobra.ExactArgs(1),
+	Long: `Acknowledge a project, or with --pattern every project matching a rule.
+
+With --wake-on the acknowledgement becomes a snooze that ends as soon as
+something changes: new prompts in the project, the repo becoming dirty,
+remote-tracking branches moving after a fetch, or a new local branch.
+
+  squirrel ack myapp --wake-on prompts,dirty
+
+Rules match paths by glob (* within a directory, ** across directories) or,
+with --regex, by regular expression. Conditions narrow a rule further:
+
+  squirrel ack --pattern '/tmp/*'
+  squirrel ack --pattern '~/**' --home-root --not-git
+  squirrel ack --pattern 'typo3' --regex --max-prompts 3`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfgPath := config.DefaultPath()
-		cfg, err := config.Load(cfgPath)
-		if err != nil {
-			return err
+		cfgPath := configPath()
+		if ackList {
+			cfg, err := squirrel.LoadConfig(cfgPath)
+			if err != nil {
+				return err
+			}
+			printAcks(cfg)
+			return nil
 		}
-
-		// Resolve project
-		cDir := claudeDir()
-		histPath := filepath.Join(cDir, "history.jsonl")
-		entries, err := claude.ParseHistory(histPath)
-		if err != nil {
-			return fmt.Errorf("reading history: %w", err)
+		if (len(args) == 1) == (ackPattern != "") {
+			return fmt.Errorf("specify either a project or --pattern")
+		}
+		if err := squirrel.ValidateWakeOn(wakeOn); err != nil {
+			return err
 		}
-		projects := claude.AggregateByProject(entries, 365)
-		project, ok := claude.FindProject(projects, args[0])
-		if !ok {
-			return fmt.Errorf("project %q not found", args[0])
+		if len(wakeOn) > 0 && ackPattern != "" {
+			return fmt.Errorf("--wake-on only applies to single projects")
 		}
 
 		var expiresAt *time.Time
 		if forDuration != "" {
-			d, err := config.ParseDuration(forDuration)
+			d, err := squirrel.ParseDuration(forDuration)
 			if err != nil {
 				return err
 			}
-			t := time.Now().Add(d)
+			t := clock().Add(d)
 			expiresAt = &t
 		}
 
-		cfg.Ack(project.Path, expiresAt)
-		if err := config.Save(cfg, cfgPath); err != nil {
+		var name string
+		err := squirrel.UpdateConfig(cfgPath, func(cfg *squirrel.Config) error {
+			if ackPattern != "" {
+				rule := squirrel.AckRule{
+					Pattern:    ackPattern,
+					Regex:      ackRegex,
+					NotGitRepo: ackNotGit,
+					MaxPrompts: ackMaxPrompts,
+					HomeRoot:   ackHomeRoot,
+					ExpiresAt:  expiresAt,
+				}
+				if err := cfg.AddAckRule(rule); err != nil {
+					return err
+				}
+				name = "rule " + rule.String()
+				return nil
+			}
+			s, err := newSquirrel(cfg)
+			if err != nil {
+				return err
+			}
+			project, err := s.Resolve(args[0])
+			if err != nil {
+				return err
+			}
+			if len(wakeOn) > 0 {
+				cfg.Snooze(project.Path, expiresAt, wakeOn, s.AckState(project))
+			} else {
+				cfg.Ack(project.Path, expiresAt)
+			}
+			name = project.ShortName
+			return nil
+		})
+		if err != nil {
 			return err
 		}
 
-		if expiresAt != nil {
-			fmt.Printf("Acknowledged %s (expires %s)\n", project.ShortName, expiresAt.Format("02.01.2006"))
-		} else {
-			fmt.Printf("Acknowledged %s (permanent)\n", project.ShortName)
+		switch {
+		case len(wakeOn) > 0 && expiresAt != nil:
+			fmt.Printf("Snoozed %s (expires %s, wakes on %s)\n", name, renderer.FormatDate(*expiresAt), strings.Join(wakeOn, ", "))
+		case len(wakeOn) > 0:
+			fmt.Printf("Snoozed %s (wakes on %s)\n", name, strings.Join(wakeOn, ", "))
+		case expiresAt != nil:
+			fmt.Printf("Acknowledged %s (expires %s)\n", name, renderer.FormatDate(*expiresAt))
+		default:
+			fmt.Printf("Acknowledged %s (permanent)\n", name)
 		}
 		return nil
 	},
 }
 
+// printAcks lists explicit acknowledgements and ack rules.
+func printAcks(cfg *squirrel.Config) {
+	acks, rules := cfg.Acks(), cfg.AckRules()
+	if len(acks) == 0 && len(rules) == 0 {
+		fmt.Println("Nothing acknowledged")
+		return
+	}
+	expiry := func(t *time.Time) string {
+		if t == nil {
+			return "permanent"
+		}
+		return "expires " + renderer.FormatDate(*t)
+	}
+	for _, a := range acks {
+		if len(a.WakeOn) > 0 {
+			fmt.Printf("%s  (%s, wakes on %s)\n", a.Path, expiry(a.ExpiresAt), strings.Join(a.WakeOn, ", "))
+			continue
+		}
+		fmt.Printf("%s  (%s)\n", a.Path, expiry(a.ExpiresAt))
+	}
+	for _, r := range rules {
+		fmt.Printf("rule: %s  (%s)\n", r.String(), expiry(r.ExpiresAt))
+	}
+}
+
 var unackCmd = &cobra.Command{
 	Use:   "unack [project]",
-	Short: "Remove acknowledgement from a project",
-	Args:  cobra.ExactArgs(1),
+	Short: "Remove acknowledgement from a project, or an ack rule with --pattern",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfgPath := config.DefaultPath()
-		cfg, err := config.Load(cfgPath)
-		if err != nil {
-			return err
+		if (len(args) == 1) == (unackPattern != "") {
+			return fmt.Errorf("specify either a project or --pattern")
 		}
 
-		// Resolve project
-		cDir := claudeDir()
-		histPath := filepath.Join(cDir, "history.jsonl")
-		entries, err := claude.ParseHistory(histPath)
-		if err != nil {
-			return fmt.Errorf("reading history: %w", err)
-		}
-		projects := claude.AggregateByProject(entries, 365)
-		project, ok := claude.FindProject(projects, args[0])
-		if !ok {
-			return fmt.Errorf("project %q not found", args[0])
+		if unackPattern != "" {
+			removed := false
+			err := squirrel.UpdateConfig(configPath(), func(cfg *squirrel.Config) error {
+				if removed = cfg.RemoveAckRule(unackPattern); !removed {
+					return squirrel.ErrUnchanged
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if removed {
+				fmt.Printf("Removed ack rule %s\n", unackPattern)
+			} else {
+				fmt.Printf("No ack rule with pattern %s\n", unackPattern)
+			}
+			return nil
 		}
 
-		if cfg.Unack(project.Path) {
-			if err := config.Save(cfg, cfgPath); err != nil {
+		var project squirrel.Project
+		var rule string
+		removed := false
+		err := squirrel.UpdateConfig(configPath(), func(cfg *squirrel.Config) error {
+			s, err := newSquirrel(cfg)
+			if err != nil {
 				return err
 			}
+			if project, err = s.Resolve(args[0]); err != nil {
+				return err
+			}
+			for _, path := range project.Paths() {
+				if cfg.Unack(path) {
+					removed = true
+				}
+			}
+			if !removed {
+				rule = s.MatchAck(project).Rule
+				return squirrel.ErrUnchanged
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case removed:
 			fmt.Printf("Removed acknowledgement for %s\n", project.ShortName)
-		} else {
+		case rule != "":
+			fmt.Printf("%s is acknowledged by rule %s (remove it with unack --pattern)\n", project.ShortName, rule)
+		default:
 			fmt.Printf("%s was not acknowledged\n", project.ShortName)
 		}
 		return nil
@@ -294,25 +521,39 @@ var unackCmd = &cobra.Command{
 
 var installSkillCmd = &cobra.Command{
 	Use:   "install-skill",
-	Short: "Install the /squirrel Claude Code skill",
+	Short: "Install the /squirrel Claude Code skill (into every --claude-dir profile)",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		skillDir := filepath.Join(claudeDir(), "skills", "squirrel")
-		skillPath := filepath.Join(skillDir, "SKILL.md")
+		for _, d := range squirrel.ClaudeDirs(claudeDirFlag...) {
+			skillDir := filepath.Join(d.Dir, "skills", "squirrel")
+			skillPath := filepath.Join(skillDir, "SKILL.md")
 
-		if err := os.MkdirAll(skillDir, 0755); err != nil {
-			return fmt.Errorf("creating skill directory: %w", err)
-		}
+			if err := os.MkdirAll(skillDir, 0755); err != nil {
+				return fmt.Errorf("creating skill directory: %w", err)
+			}
 
-		if err := os.WriteFile(skillPath, []byte(skillContent), 0644); err != nil {
-			return fmt.Errorf("writing skill file: %w", err)
-		}
+			if err := os.WriteFile(skillPath, []byte(skillText()), 0644); err != nil {
+				return fmt.Errorf("writing skill file: %w", err)
+			}
 
-		fmt.Printf("Skill installed to %s\n", skillPath)
+			fmt.Printf("Skill installed to %s\n", skillPath)
+		}
 		fmt.Println("You can now use /squirrel in any Claude Code session.")
 		return nil
 	},
 }
 
+// skillText returns the skill with its user-facing phrases in the current
+// language.
+func skillText() string {
+	return strings.NewReplacer(
+		"{{language}}", renderer.Message("skill.language"),
+		"{{recommendations}}", renderer.Message("skill.recommendations"),
+		"{{summary}}", renderer.Message("skill.summary"),
+		"{{ask}}", renderer.Message("skill.ask"),
+		"{{cd}}", renderer.Message("skill.cd"),
+	).Replace(skillContent)
+}
+
 const skillContent = `---
 name: squirrel
 description: Find forgotten Claude Code projects - shows open work, activity timeline, and recommendations
@@ -322,6 +563,7 @@ user_invocable: true
 # Squirrel - Find Your Forgotten Projects
 
 Run the squirrel CLI to analyze Claude Code history and present results.
+{{language}}
 
 ## Steps
 
@@ -331,28 +573,31 @@ Run the squirrel CLI to analyze Claude Code history and present results.
    squirrel status --json --deep --days 14
    ` + "```" + `
 
-2. Parse the JSON output and present the results in a structured way:
+2. Parse the JSON output (schemaVersion 1, see ` + "`squirrel schema`" + `) and present the results in a structured way:
 
    **For each category (openWork, recentActivity, sleeping):**
    - Show the project name, last activity date, prompt count
    - For open work: highlight uncommitted files and feature branches
    - For sleeping projects: show days since last activity
+   - If a project has ` + "`notes`" + `, show the newest note as the user's "next step"
+   - Projects with ` + "`reminderDue: true`" + ` come first: the user asked to be reminded about them
 
 3. After presenting the overview, provide:
-   - **Top 3 Empfehlungen:** Which projects the user should focus on (highest score)
-   - **Quick Summary:** "Du hast X offene Baustellen, Y aktive Projekte und Z schlafende Projekte"
+   - **{{recommendations}}:** Which projects the user should focus on (highest score)
+   - **Quick Summary:** "{{summary}}"
 
-4. Ask the user: "An welchem Projekt moechtest du weiterarbeiten?"
+4. Ask the user: "{{ask}}"
 
 5. When the user picks a project:
    - Show the last session summary for that project
    - Show the last few prompts from history
-   - Suggest: "Soll ich in das Projektverzeichnis wechseln?"
+   - Suggest: "{{cd}}"
 
 ## Notes
 
 - The --deep flag takes longer but provides richer context
 - Use --days 30 for a broader view
+- Use --since and --until (e.g. --since last-week --until last-week) for a specific period
 `
 
 var nutsCmd = &cobra.Command{
@@ -394,24 +639,77 @@ func resolveDepthShortcuts(cmd *cobra.Command) {
 }
 
 func init() {
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := applyDefaults(cmd, args); err != nil {
+			return err
+		}
+		if _, err := outputFormat(); err != nil {
+			return err
+		}
+		var err error
+		if window, err = squirrel.ParseRange(sinceExpr, untilExpr, days, clock()); err != nil {
+			return err
+		}
+		if lang == "" {
+			lang = squirrel.DetectLanguage()
+		}
+		return setupRenderer()
+	}
+
 	pf := rootCmd.PersistentFlags()
 	pf.StringVar(&depth, "depth", "medium", "Analysis depth: quick, medium, or deep")
 	pf.BoolVar(&jsonOut, "json", false, "Output as JSON (for skill integration)")
+	pf.StringVar(&format, "format", "terminal", "Output format: "+strings.Join(formats, ", "))
+	pf.StringVar(&templatePath, "template", "", "Render output with a Go text/template file")
+	pf.StringSliceVar(&fields, "fields", nil, "Columns for csv, tsv and ndjson output (default: all, see README)")
 	pf.IntVar(&days, "days", 14, "Number of days to look back")
-
-	for _, cmd := range []*cobra.Command{statusCmd, projectCmd} {
+	pf.StringVar(&sinceExpr, "since", "", "Start of the range: YYYY-MM-DD or "+strings.Join(squirrel.RangeExpressions, ", ")+" (overrides --days)")
+	pf.StringVar(&untilExpr, "until", "", "End of the range, inclusive: YYYY-MM-DD or a relative expression like --since (default now)")
+	pf.BoolVar(&allHosts, "all-hosts", false, "Include projects from other machines in the sync directory")
+	pf.StringSliceVar(&tagFilter, "tag", nil, "Only show projects with any of these tags")
+	pf.StringSliceVar(&groupFilter, "group", nil, "Only show projects in any of these groups")
+	pf.BoolVar(&includeIgnored, "include-ignored", false, "Also show ignored projects (for auditing the ignore list)")
+	pf.StringSliceVar(&claudeDirFlag, "claude-dir", nil, "Claude data directories to read, as dir or name=dir (default $CLAUDE_CONFIG_DIR or ~/.claude)")
+	pf.StringVar(&colorMode, "color", "auto", "Colour output: "+strings.Join(squirrel.ColorModes, ", ")+" (auto honours NO_COLOR and non-terminal output)")
+	pf.StringVar(&lang, "lang", "", "Output language: "+strings.Join(squirrel.Languages(), ", ")+" (default from $LC_ALL, $LC_MESSAGES or $LANG)")
+	pf.StringVar(&configFile, "config", "", "Config file (default $XDG_CONFIG_HOME/squirrel/config.json)")
+
+	for _, cmd := range []*cobra.Command{statusCmd, projectCmd, reportCmd} {
 		cmd.Flags().Bool("quick", false, "Shortcut for --depth=quick")
 		cmd.Flags().Bool("medium", false, "Shortcut for --depth=medium")
 		cmd.Flags().Bool("deep", false, "Shortcut for --depth=deep")
 	}
 
+	timelineCmd.Flags().StringVar(&timelineBy, "by", "day", "Bucket size: "+strings.Join(squirrel.TimelinePeriods, ", "))
+
 	ackCmd.Flags().StringVar(&forDuration, "for", "", "Duration (e.g. 7d, 2w, 3m)")
+	ackCmd.Flags().StringVar(&ackPattern, "pattern", "", "Acknowledge all projects matching a path glob")
+	ackCmd.Flags().BoolVar(&ackRegex, "regex", false, "Treat --pattern as a regular expression")
+	ackCmd.Flags().BoolVar(&ackNotGit, "not-git", false, "Rule only matches directories that are not git repos")
+	ackCmd.Flags().IntVar(&ackMaxPrompts, "max-prompts", 0, "Rule only matches projects with fewer prompts than this")
+	ackCmd.Flags().BoolVar(&ackHomeRoot, "home-root", false, "Rule only matches the home directory and its direct children")
+	ackCmd.Flags().BoolVar(&ackList, "list", false, "List acknowledgements and ack rules")
+	ackCmd.Flags().StringSliceVar(&wakeOn, "wake-on", nil, "Wake up early on events: prompts, dirty, remote, branch")
+	unackCmd.Flags().StringVar(&unackPattern, "pattern", "", "Remove the ack rule with this pattern")
 	rootCmd.AddCommand(ackCmd)
 	rootCmd.AddCommand(unackCmd)
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(stashCmd)
 	rootCmd.AddCommand(timelineCmd)
+	rootCmd.AddCommand(heatmapCmd)
+	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(projectCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(ignoreCmd)
+	rootCmd.AddCommand(unignoreCmd)
+	rootCmd.AddCommand(ignoredCmd)
+	rootCmd.AddCommand(noteCmd)
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(untagCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(schemaCmd)
+	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(installSkillCmd)
 	rootCmd.AddCommand(nutsCmd)
 }
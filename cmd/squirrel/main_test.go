@@ -0,0 +1,392 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/dkd-dobberkau/squirrel/internal/output"
+)
+
+// fixtureNow is the fixed time the end-to-end tests run at, a Monday.
+var fixtureNow = time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+
+// fakeGit answers for two repositories: /src/app is dirty on a feature
+// branch, /src/lib is clean on main. Every other path is no repository.
+func fakeGit(path string, args ...string) (string, error) {
+	branch := map[string]string{"/src/app": "feature/login", "/src/lib": "main"}[path]
+	if branch == "" {
+		return "", errors.New("not a git repository")
+	}
+	switch strings.Join(args, " ") {
+	case "rev-parse --git-dir":
+		return ".git\n", nil
+	case "rev-parse --abbrev-ref HEAD":
+		return branch + "\n", nil
+	case "status --porcelain":
+		if path == "/src/app" {
+			return " M form.go\n?? form_test.go\n", nil
+		}
+	}
+	return "", nil
+}
+
+func TestMain(m *testing.M) {
+	time.Local = time.UTC
+	clock = func() time.Time { return fixtureNow }
+	gitRunner = fakeGit
+	os.Exit(m.Run())
+}
+
+// run executes squirrel with args against the fixture .claude tree and the
+// config file cfg, and returns what it wrote to stdout.
+func run(t *testing.T, cfg string, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("COLUMNS", "")
+	resetFlags(rootCmd)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+
+	rootCmd.SetOut(io.Discard) // usage on errors
+	rootCmd.SetErr(io.Discard)
+	rootCmd.SetArgs(append([]string{
+		"--claude-dir", filepath.Join("testdata", "claude"),
+		"--config", cfg,
+		"--lang", "en",
+		"--color", "never",
+	}, args...))
+	err = rootCmd.Execute()
+	w.Close()
+	return <-done, err
+}
+
+// resetFlags restores every flag to its default, as cobra keeps the values
+// of earlier runs.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			s.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
+func tempConfig(t *testing.T) string {
+	return filepath.Join(t.TempDir(), "config.json")
+}
+
+func decode[T any](t *testing.T, out string) T {
+	t.Helper()
+	var doc T
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("decoding output: %v\n%s", err, out)
+	}
+	return doc
+}
+
+func names(projects []output.Project) []string {
+	var out []string
+	for _, p := range projects {
+		out = append(out, p.ShortName)
+	}
+	return out
+}
+
+func TestStatusJSON(t *testing.T) {
+	out, err := run(t, tempConfig(t), "status", "--json", "--days", "30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := decode[output.StatusDocument](t, out)
+
+	if got := names(doc.OpenWork); len(got) != 1 || got[0] != "app" {
+		t.Fatalf("openWork = %v, want [app]", got)
+	}
+	app := doc.OpenWork[0]
+	if !app.GitDirty || app.GitBranch != "feature/login" || app.UncommittedFiles != 2 {
+		t.Errorf("app git state = dirty %v, branch %q, %d files", app.GitDirty, app.GitBranch, app.UncommittedFiles)
+	}
+	if app.PromptCount != 3 || app.SessionCount != 1 || app.LatestSummary != "Login form" {
+		t.Errorf("app = %d prompts, %d sessions, summary %q", app.PromptCount, app.SessionCount, app.LatestSummary)
+	}
+	if got := names(doc.RecentActivity); len(got) != 1 || got[0] != "lib" {
+		t.Errorf("recentActivity = %v, want [lib]", got)
+	}
+	if len(doc.Sleeping) != 1 || doc.Sleeping[0].ShortName != "old" || doc.Sleeping[0].DaysSinceActive != 19 {
+		t.Errorf("sleeping = %+v, want old, 19 days inactive", doc.Sleeping)
+	}
+}
+
+func TestStatusAsOfPastDate(t *testing.T) {
+	out, err := run(t, tempConfig(t), "status", "--json", "--until", "2026-02-25", "--days", "14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := decode[output.StatusDocument](t, out)
+
+	if got := names(doc.RecentActivity); len(got) != 1 || got[0] != "old" {
+		t.Fatalf("recentActivity = %v, want [old] as of Feb 25", got)
+	}
+	// Last prompt Feb 24 14:00, the range ends with Feb 25
+	if doc.RecentActivity[0].DaysSinceActive != 1 {
+		t.Errorf("daysSinceActive = %d, want 1", doc.RecentActivity[0].DaysSinceActive)
+	}
+	if len(doc.OpenWork)+len(doc.Sleeping) != 0 {
+		t.Errorf("projects active after --until leaked in: %v %v", names(doc.OpenWork), names(doc.Sleeping))
+	}
+}
+
+func TestUntilDefault(t *testing.T) {
+	check := func(name, cfg string) {
+		t.Helper()
+		out, err := run(t, cfg, "status", "--json")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := names(decode[output.StatusDocument](t, out).RecentActivity); len(got) != 1 || got[0] != "old" {
+			t.Errorf("%s: recentActivity = %v, want [old] as of Feb 25", name, got)
+		}
+	}
+
+	cfg := tempConfig(t)
+	if _, err := run(t, cfg, "config", "set", "defaults.until", "2026-02-25"); err != nil {
+		t.Fatal(err)
+	}
+	check("config", cfg)
+
+	t.Setenv("SQUIRREL_UNTIL", "2026-02-25")
+	check("environment", tempConfig(t))
+}
+
+func TestActionFlagsTakeNoDefaults(t *testing.T) {
+	cfg := tempConfig(t)
+	for _, key := range []string{"defaults.pattern", "commands.report.html", "commands.ack.list"} {
+		if _, err := run(t, cfg, "config", "set", key, "x"); err == nil || !strings.Contains(err.Error(), "cannot have a default") {
+			t.Errorf("config set %s: err = %v", key, err)
+		}
+	}
+
+	t.Setenv("SQUIRREL_PATTERN", "/tmp/*")
+	if out, err := run(t, cfg, "ack", "lib"); err != nil || !strings.Contains(out, "lib") {
+		t.Errorf("ack = %q, %v", out, err)
+	}
+	if out, err := run(t, cfg, "unack", "lib"); err != nil || out != "Removed acknowledgement for lib\n" {
+		t.Errorf("unack = %q, %v", out, err)
+	}
+}
+
+func TestProjectDeep(t *testing.T) {
+	out, err := run(t, tempConfig(t), "project", "app", "--depth", "deep", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := decode[output.ProjectDocument](t, out)
+
+	if len(doc.Project.Todos) != 1 || doc.Project.Todos[0].Text != "validate email addresses" {
+		t.Errorf("todos = %+v", doc.Project.Todos)
+	}
+	if len(doc.RecentPrompts) != 3 || doc.RecentPrompts[0].Text != "add login form validation" {
+		t.Errorf("recentPrompts = %+v", doc.RecentPrompts)
+	}
+
+	if _, err := run(t, tempConfig(t), "project", "nope"); err == nil || !strings.Contains(err.Error(), `"nope" not found`) {
+		t.Errorf("unknown project: err = %v", err)
+	}
+}
+
+func TestAckAndUnack(t *testing.T) {
+	cfg := tempConfig(t)
+	if out, err := run(t, cfg, "ack", "lib", "--for", "1w"); err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(out, "lib") {
+		t.Errorf("ack output = %q", out)
+	}
+
+	out, err := run(t, cfg, "status", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := decode[output.StatusDocument](t, out)
+	if got := names(doc.Acknowledged); len(got) != 1 || got[0] != "lib" {
+		t.Errorf("acknowledged = %v, want [lib]", got)
+	}
+	if out, err := run(t, cfg, "project", "lib", "--format", "csv", "--fields", "name,acknowledged"); err != nil || out != "name,acknowledged\nlib,true\n" {
+		t.Errorf("project csv = %q, %v", out, err)
+	}
+	if out, err := run(t, cfg, "project", "lib", "--json"); err != nil || !decode[output.ProjectDocument](t, out).Acknowledged {
+		t.Errorf("project json not acknowledged: %q, %v", out, err)
+	}
+
+	// A week later the acknowledgement has expired
+	clock = func() time.Time { return fixtureNow.AddDate(0, 0, 8) }
+	out, err = run(t, cfg, "status", "--json", "--days", "30")
+	clock = func() time.Time { return fixtureNow }
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc := decode[output.StatusDocument](t, out); len(doc.Acknowledged) != 0 {
+		t.Errorf("acknowledged after expiry = %v", names(doc.Acknowledged))
+	}
+
+	if out, err := run(t, cfg, "unack", "lib"); err != nil || out != "Removed acknowledgement for lib\n" {
+		t.Errorf("unack = %q, %v", out, err)
+	}
+}
+
+func TestStatsJSON(t *testing.T) {
+	out, err := run(t, tempConfig(t), "stats", "--json", "--since", "2026-03-09", "--until", "2026-03-15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := decode[output.StatsDocument](t, out)
+
+	if doc.Days != 7 || doc.Prompts != 5 || doc.ActiveDays != 4 {
+		t.Errorf("days %d, prompts %d, active days %d; want 7, 5, 4", doc.Days, doc.Prompts, doc.ActiveDays)
+	}
+	if doc.Sessions != 1 || doc.AvgSessionMessages != 24 {
+		t.Errorf("sessions %d with %.1f messages, want 1 with 24", doc.Sessions, doc.AvgSessionMessages)
+	}
+}
+
+func TestStatsKeepAcknowledgedProjects(t *testing.T) {
+	cfg := tempConfig(t)
+	stats := func() output.StatsDocument {
+		t.Helper()
+		out, err := run(t, cfg, "stats", "--json", "--until", "2026-03-16", "--days", "30")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return decode[output.StatsDocument](t, out)
+	}
+
+	before := stats()
+	if _, err := run(t, cfg, "ack", "lib"); err != nil {
+		t.Fatal(err)
+	}
+	after := stats()
+	if after.Prompts != before.Prompts || after.ActiveDays != before.ActiveDays || after.Sessions != before.Sessions {
+		t.Errorf("after ack: prompts %d, active days %d, sessions %d; want %d, %d, %d",
+			after.Prompts, after.ActiveDays, after.Sessions, before.Prompts, before.ActiveDays, before.Sessions)
+	}
+}
+
+func TestTimelineKeepsAcknowledgedProjects(t *testing.T) {
+	cfg := tempConfig(t)
+	if _, err := run(t, cfg, "ack", "lib"); err != nil {
+		t.Fatal(err)
+	}
+	out, err := run(t, cfg, "timeline", "--json", "--by", "month", "--until", "2026-03-16", "--days", "30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := decode[output.TimelineDocument](t, out)
+	if len(doc.Buckets) == 0 || doc.Buckets[0].Start.Month() != time.March {
+		t.Fatalf("buckets = %+v, want March first", doc.Buckets)
+	}
+	march := doc.Buckets[0]
+	var got []string
+	for _, p := range march.Projects {
+		got = append(got, p.ShortName)
+	}
+	if march.Prompts != 5 || !slices.Contains(got, "lib") {
+		t.Errorf("March: %d prompts in %v, want 5 including the acknowledged lib", march.Prompts, got)
+	}
+}
+
+func TestTerminalOutput(t *testing.T) {
+	out, err := run(t, tempConfig(t), "timeline", "--since", "last-week", "--until", "last-week")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Sun, Mar 15, 2026 (2 prompts)", "Login form (24 msgs)", "Tue, Mar 10, 2026 (1 prompt)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("timeline output lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestHeatmapEndsWithUntil(t *testing.T) {
+	out, err := run(t, tempConfig(t), "report", "--html", "-", "--until", "2026-02-25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Report Feb 25, 2026") || !strings.Contains(out, `title="2026-02-25: 0 prompts"`) || strings.Contains(out, "2026-02-26") {
+		t.Errorf("report does not end with Feb 25")
+	}
+
+	// Friday Feb 20 is the last row of the grid's only week
+	if out, err = run(t, tempConfig(t), "heatmap", "--until", "2026-02-20", "--weeks", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if cells := strings.Count(out, ". \n") + strings.Count(out, "# \n"); cells != 5 {
+		t.Errorf("heatmap shows %d days, want Monday to Friday:\n%s", cells, out)
+	}
+}
+
+func TestTemplateAsOfPastDate(t *testing.T) {
+	out, err := run(t, tempConfig(t), "status", "--format", "oneline", "--until", "2026-02-25", "--days", "14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Last prompt Feb 24, the range ends with Feb 25
+	if !strings.Contains(out, "old (yesterday)") {
+		t.Errorf("oneline output = %q, want old active yesterday", out)
+	}
+}
+
+func TestConfigEditKeepsConcurrentChanges(t *testing.T) {
+	cfg := tempConfig(t)
+	if _, err := run(t, cfg, "ack", "lib"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The editor sets the host; the config itself is untouched meanwhile
+	t.Setenv("VISUAL", `printf '{"host":"edited"}' >`)
+	if _, err := run(t, cfg, "config", "edit"); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(cfg); !strings.Contains(string(data), `"host": "edited"`) {
+		t.Fatalf("edit not saved:\n%s", data)
+	}
+
+	// An ack lands while the editor is open
+	t.Setenv("VISUAL", `printf '{"host":"other"}' > '`+cfg+`'; printf '{"host":"late"}' >`)
+	_, err := run(t, cfg, "config", "edit")
+	if err == nil || !strings.Contains(err.Error(), "changed while you were editing") {
+		t.Fatalf("concurrent change: err = %v", err)
+	}
+	if data, _ := os.ReadFile(cfg); !strings.Contains(string(data), `"other"`) {
+		t.Errorf("concurrent change overwritten:\n%s", data)
+	}
+	kept, _ := filepath.Glob(filepath.Join(filepath.Dir(cfg), "config-edit-*.json"))
+	if len(kept) != 1 {
+		t.Errorf("edit not kept: %v", kept)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var remindIn string
+
+var noteCmd = &cobra.Command{
+	Use:   "note [project] [text]",
+	Short: "Leave a note on a project, or list its notes when no text is given",
+	Long: `Leave a breadcrumb on a project before parking it. Notes are kept as history;
+the newest one is shown as the next step.
+
+With --remind the project resurfaces at the top of Open Work once the
+reminder is due. Adding another note afterwards dismisses the reminder.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfgPath := configPath()
+		if len(args) == 1 {
+			if remindIn != "" {
+				return fmt.Errorf("--remind needs note text")
+			}
+			cfg, err := squirrel.LoadConfig(cfgPath)
+			if err != nil {
+				return err
+			}
+			project, err := resolveProject(cfg, args[0])
+			if err != nil {
+				return err
+			}
+			notes := cfg.NotesFor(project.Paths()...)
+			if len(notes) == 0 {
+				fmt.Printf("%s has no notes\n", project.ShortName)
+				return nil
+			}
+			for _, n := range notes {
+				line := fmt.Sprintf("%s  %s", renderer.FormatDateTime(n.CreatedAt), n.Text)
+				if n.RemindAt != nil {
+					line += fmt.Sprintf("  (reminder %s)", renderer.FormatDate(*n.RemindAt))
+				}
+				fmt.Println(line)
+			}
+			return nil
+		}
+
+		var remindAt *time.Time
+		if remindIn != "" {
+			t, err := parseRemindAt(remindIn)
+			if err != nil {
+				return err
+			}
+			remindAt = &t
+		}
+
+		var project squirrel.Project
+		err := squirrel.UpdateConfig(cfgPath, func(cfg *squirrel.Config) error {
+			var err error
+			if project, err = resolveProject(cfg, args[0]); err != nil {
+				return err
+			}
+			cfg.AddNote(project.Path, strings.Join(args[1:], " "), remindAt)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if remindAt != nil {
+			fmt.Printf("Noted for %s (reminder %s)\n", project.ShortName, renderer.FormatDate(*remindAt))
+		} else {
+			fmt.Printf("Noted for %s\n", project.ShortName)
+		}
+		return nil
+	},
+}
+
+// parseRemindAt accepts a duration like "3d" or a date like "2026-03-01".
+func parseRemindAt(s string) (time.Time, error) {
+	if d, err := squirrel.ParseDuration(s); err == nil {
+		return clock().Add(d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid reminder %q (use e.g. 3d, 2w or 2026-03-01)", s)
+}
+
+func init() {
+	noteCmd.Flags().StringVar(&remindIn, "remind", "", "Resurface the project after a duration (e.g. 3d, 2w) or on a date (YYYY-MM-DD)")
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var reportHTML string
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Write a self-contained HTML dashboard",
+	Long: `Write all projects to a single HTML file with sortable tables, a 12-week
+activity heatmap per project and expandable sessions and TODOs. The file
+has no external dependencies and can be shared or archived as is.
+
+  squirrel report --html squirrel.html
+  squirrel report --html - --deep > squirrel.html`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if reportHTML == "" {
+			return fmt.Errorf("specify the output file with --html (- for stdout)")
+		}
+		resolveDepthShortcuts(cmd)
+
+		s, err := loadSquirrel()
+		if err != nil {
+			return err
+		}
+		data, err := s.Analyze()
+		if err != nil {
+			return err
+		}
+		entries, err := s.History()
+		if err != nil {
+			return err
+		}
+
+		html, err := renderer.RenderHTML(data, entries, window.Until.Add(-time.Nanosecond))
+		if err != nil {
+			return fmt.Errorf("rendering report: %w", err)
+		}
+
+		if reportHTML == "-" {
+			fmt.Print(html)
+			return nil
+		}
+		if err := os.WriteFile(reportHTML, []byte(html), 0644); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+		fmt.Printf("Report written to %s\n", reportHTML)
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportHTML, "html", "", "Write the report to this HTML file (- for stdout)")
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [status|project|timeline|stats]",
+	Short: "Print the JSON Schema of the --json output",
+	Long: `Print the JSON Schema describing --json output: "status" for status and
+stash (the default), "project" for the project command, "timeline" for the
+timeline command and "stats" for the stats command. Every document carries
+a schemaVersion that changes only on incompatible changes.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := "status"
+		if len(args) == 1 {
+			name = args[0]
+		}
+		s, err := squirrel.Schema(name)
+		if err != nil {
+			return err
+		}
+		fmt.Print(s)
+		return nil
+	},
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel/server"
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var (
+	serveAddr    string
+	serveRefresh time.Duration
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the analysis as a local JSON API",
+	Long: `Serve the analysis over HTTP for editor plugins and launcher scripts. The
+result is cached and refreshed in the background every --refresh, so
+requests are answered without parsing the history again.
+
+  GET  /projects          the status document (squirrel schema status)
+  GET  /projects/{id}     the document of a project active in the range; id
+                          is a name, path or path suffix
+  GET  /timeline?by=week  the timeline document, by day (default), week or month
+  GET  /stats             the stats document
+  POST /ack               acknowledge a project: {"project": "myapp", "for": "2w"},
+                          optionally with "wakeOn": ["prompts", "dirty"]
+
+--days, --since and --until are resolved anew on every refresh, so "the
+last 14 days" move along with the clock. The server listens on localhost
+by default and answers only requests addressed to it, and acknowledges
+only JSON requests from no other origin, so web pages cannot use it. It
+has no authentication, so think twice before binding it to other
+interfaces.
+
+  squirrel serve
+  squirrel serve --addr 127.0.0.1:8080 --refresh 5m --depth deep
+  curl -s localhost:7777/projects/myapp`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if serveRefresh <= 0 {
+			return fmt.Errorf("--refresh must be positive")
+		}
+		srv := &server.Server{
+			Load: func() (*squirrel.Squirrel, error) {
+				cfg, err := squirrel.LoadConfig(configPath())
+				if err != nil {
+					return nil, err
+				}
+				return serveSquirrel(cfg)
+			},
+			Ack:     serveAck,
+			Refresh: serveRefresh,
+			Now:     clock,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := srv.Run(ctx); err != nil {
+			return err
+		}
+
+		ln, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return err
+		}
+		srv.Addr = ln.Addr().String()
+		hs := &http.Server{Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			<-ctx.Done()
+			shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			hs.Shutdown(shutdown)
+		}()
+
+		fmt.Printf("Serving on http://%s (refreshing every %s)\n", ln.Addr(), serveRefresh)
+		if err := hs.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+// serveSquirrel returns a Squirrel for cfg whose range is resolved against
+// the current time, as the server outlives the range resolved at startup.
+func serveSquirrel(cfg *squirrel.Config) (*squirrel.Squirrel, error) {
+	r, err := squirrel.ParseRange(sinceExpr, untilExpr, days, clock())
+	if err != nil {
+		return nil, err
+	}
+	return squirrel.New(append(squirrelOptions(cfg), squirrel.WithRange(r))...)
+}
+
+// serveAck acknowledges a project for POST /ack, like squirrel ack.
+func serveAck(query string, expiresAt *time.Time, wakeOn []string) (squirrel.Project, error) {
+	var project squirrel.Project
+	err := squirrel.UpdateConfig(configPath(), func(cfg *squirrel.Config) error {
+		s, err := serveSquirrel(cfg)
+		if err != nil {
+			return err
+		}
+		if project, err = s.Resolve(query); err != nil {
+			return err
+		}
+		if len(wakeOn) > 0 {
+			cfg.Snooze(project.Path, expiresAt, wakeOn, s.AckState(project))
+		} else {
+			cfg.Ack(project.Path, expiresAt)
+		}
+		return nil
+	})
+	return project, err
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:7777", "Address to listen on")
+	serveCmd.Flags().DurationVar(&serveRefresh, "refresh", time.Minute, "Interval of background refreshes")
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show productivity statistics",
+	Long: `Show metrics computed from your prompt history and sessions: prompts per day
+and week, active days and streaks, the busiest hours, session length,
+projects per week, how often you switch between projects, and how many
+projects went to sleep with open work.
+
+The range defaults to the last --days days; --since and --until select
+another one.
+
+  squirrel stats
+  squirrel stats --since 2026-09-01 --until 2026-09-30 --json
+  squirrel stats --since last-month --until last-month`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := outputFormat()
+		if err != nil {
+			return err
+		}
+		if f != "terminal" && f != "json" {
+			return fmt.Errorf("stats supports --format terminal and json")
+		}
+
+		s, err := loadSquirrel()
+		if err != nil {
+			return err
+		}
+		st, err := s.Stats()
+		if err != nil {
+			return err
+		}
+
+		if f == "json" {
+			out, err := squirrel.RenderStatsJSON(st)
+			if err != nil {
+				return err
+			}
+			fmt.Println(out)
+			return nil
+		}
+		fmt.Print(renderer.RenderStats(st))
+		return nil
+	},
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var syncDir string
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Exchange acknowledgements, notes and project snapshots with other machines",
+	Long: `Sync imports acknowledgements and notes from other machines' snapshots in
+the sync directory (last writer wins per acknowledgement), then exports this
+machine's state.
+
+The directory can be a mounted share or a git repository you commit and pull
+yourself. Each machine writes only its own <host>.json file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var res squirrel.SyncResult
+		err := squirrel.UpdateConfig(configPath(), func(cfg *squirrel.Config) error {
+			if syncDir != "" {
+				abs, err := filepath.Abs(syncDir)
+				if err != nil {
+					return err
+				}
+				cfg.SetSyncDir(abs)
+			}
+			if cfg.SyncDir() == "" {
+				return fmt.Errorf("no sync directory configured (use --dir)")
+			}
+
+			s, err := newSquirrel(cfg)
+			if err != nil {
+				return err
+			}
+			res, err = s.Sync()
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Imported %d acknowledgement and note changes from %d other hosts\n", res.Imported, res.Hosts)
+		fmt.Printf("Exported %d projects to %s\n", res.Exported, res.File)
+		return nil
+	},
+}
+
+func init() {
+	syncCmd.Flags().StringVar(&syncDir, "dir", "", "Sync directory (remembered in the config)")
+}
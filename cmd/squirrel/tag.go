@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag [project] [tag...]",
+	Short: "Tag a project, or list its tags when no tags are given",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfgPath := configPath()
+		if len(args) == 1 {
+			cfg, err := squirrel.LoadConfig(cfgPath)
+			if err != nil {
+				return err
+			}
+			project, err := resolveProject(cfg, args[0])
+			if err != nil {
+				return err
+			}
+			tags := cfg.TagsFor(project.Paths()...)
+			if len(tags) == 0 {
+				fmt.Printf("%s has no tags\n", project.ShortName)
+			} else {
+				fmt.Printf("%s: %s\n", project.ShortName, strings.Join(tags, ", "))
+			}
+			return nil
+		}
+
+		var project squirrel.Project
+		var added []string
+		err := squirrel.UpdateConfig(cfgPath, func(cfg *squirrel.Config) error {
+			var err error
+			if project, err = resolveProject(cfg, args[0]); err != nil {
+				return err
+			}
+			if added = cfg.Tag(project.Path, args[1:]...); len(added) == 0 {
+				return squirrel.ErrUnchanged
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(added) == 0 {
+			fmt.Printf("%s already has these tags\n", project.ShortName)
+			return nil
+		}
+		fmt.Printf("Tagged %s: %s\n", project.ShortName, strings.Join(added, ", "))
+		return nil
+	},
+}
+
+var untagCmd = &cobra.Command{
+	Use:   "untag [project] [tag...]",
+	Short: "Remove tags from a project (all tags if none are given)",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var project squirrel.Project
+		removed := 0
+		err := squirrel.UpdateConfig(configPath(), func(cfg *squirrel.Config) error {
+			var err error
+			if project, err = resolveProject(cfg, args[0]); err != nil {
+				return err
+			}
+			for _, path := range project.Paths() {
+				removed += cfg.Untag(path, args[1:]...)
+			}
+			if removed == 0 {
+				return squirrel.ErrUnchanged
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			fmt.Printf("%s had no matching tags\n", project.ShortName)
+			return nil
+		}
+		fmt.Printf("Removed %d tags from %s\n", removed, project.ShortName)
+		return nil
+	},
+}
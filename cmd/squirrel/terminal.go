@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/x/term"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var colorMode string
+
+// terminalWidth returns the width of the terminal on stdout. When stdout is
+// not a terminal it returns $COLUMNS, or 0 so that lines are not fitted.
+func terminalWidth() int {
+	if w, _, err := term.GetSize(os.Stdout.Fd()); err == nil && w > 0 {
+		return w
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return 0
+}
+
+// renderer renders the output of the current command; see setupRenderer.
+var renderer *squirrel.Renderer
+
+// setupRenderer sets up the renderer for --lang, --color and the terminal
+// width.
+func setupRenderer() error {
+	var err error
+	renderer, err = squirrel.NewRenderer(
+		squirrel.WithLanguage(lang),
+		squirrel.WithColor(colorMode),
+		squirrel.WithWidth(terminalWidth()),
+	)
+	return err
+}
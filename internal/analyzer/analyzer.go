@@ -14,9 +14,14 @@ type CategorizedProjects struct {
 	RecentActivity []claude.ProjectInfo `json:"recentActivity"`
 	Sleeping       []claude.ProjectInfo `json:"sleeping"`
 	Acknowledged   []claude.ProjectInfo `json:"acknowledged"`
+	// Ignored is only filled when ignored projects are explicitly requested.
+	Ignored []claude.ProjectInfo `json:"ignored,omitempty"`
+	// Groups lists the project groups present in the result, in config order.
+	Groups []string `json:"groups,omitempty"`
 }
 
 // Categorize sorts projects into open work, recent activity, sleeping, and acknowledged.
+// Projects with a due reminder are always open work and listed first, even if acknowledged.
 func Categorize(projects []claude.ProjectInfo, ackedPaths map[string]bool) CategorizedProjects {
 	var result CategorizedProjects
 
@@ -24,6 +29,11 @@ func Categorize(projects []claude.ProjectInfo, ackedPaths map[string]bool) Categ
 		p.Score = Score(p)
 		p.IsOpenWork = isOpenWork(p)
 
+		if p.ReminderDue {
+			result.OpenWork = append(result.OpenWork, p)
+			continue
+		}
+
 		if ackedPaths[p.Path] {
 			result.Acknowledged = append(result.Acknowledged, p)
 			continue
@@ -41,6 +51,9 @@ func Categorize(projects []claude.ProjectInfo, ackedPaths map[string]bool) Categ
 
 	sortByScore := func(s []claude.ProjectInfo) {
 		sort.Slice(s, func(i, j int) bool {
+			if s[i].ReminderDue != s[j].ReminderDue {
+				return s[i].ReminderDue
+			}
 			return s[i].Score > s[j].Score
 		})
 	}
@@ -92,15 +105,36 @@ func Score(p claude.ProjectInfo) float64 {
 	return score
 }
 
-// EnrichWithGit adds git status data to projects (medium depth).
+// EnrichWithGit adds git status data to projects (medium depth). A project
+// is dirty if any of its checkouts is, counting the uncommitted files of
+// all of them; the branch is that of the first checkout, canonical path
+// first. Projects from other machines keep the git state recorded there.
 func EnrichWithGit(projects []claude.ProjectInfo) {
+	enrichWithGit(gitpkg.Exec, projects)
+}
+
+func enrichWithGit(git gitpkg.Runner, projects []claude.ProjectInfo) {
 	for i := range projects {
-		status, err := gitpkg.CheckStatus(projects[i].Path)
-		if err != nil || !status.IsRepo {
+		if projects[i].Host != "" {
+			continue
+		}
+		var sum gitpkg.RepoStatus
+		for _, path := range projects[i].Paths() {
+			status, err := git.CheckStatus(path)
+			if err != nil || !status.IsRepo {
+				continue
+			}
+			if !sum.IsRepo {
+				sum.IsRepo, sum.Branch = true, status.Branch
+			}
+			sum.IsDirty = sum.IsDirty || status.IsDirty
+			sum.UncommittedFiles += status.UncommittedFiles
+		}
+		if !sum.IsRepo {
 			continue
 		}
-		projects[i].GitDirty = status.IsDirty
-		projects[i].GitBranch = status.Branch
-		projects[i].UncommittedFiles = status.UncommittedFiles
+		projects[i].GitDirty = sum.IsDirty
+		projects[i].GitBranch = sum.Branch
+		projects[i].UncommittedFiles = sum.UncommittedFiles
 	}
 }
@@ -1,12 +1,48 @@
 package analyzer
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/dkd-dobberkau/squirrel/internal/claude"
 )
 
+func TestEnrichWithGitAliases(t *testing.T) {
+	// The canonical checkout is clean, the alias has two uncommitted files
+	git := func(path string, args ...string) (string, error) {
+		switch strings.Join(args, " ") {
+		case "rev-parse --git-dir":
+			if path == "/missing" {
+				return "", errors.New("not a repository")
+			}
+			return ".git\n", nil
+		case "rev-parse --abbrev-ref HEAD":
+			return map[string]string{"/home/me/x": "main\n", "/srv/x": "fix/login\n"}[path], nil
+		case "status --porcelain":
+			if path == "/srv/x" {
+				return " M a.go\n?? b.go\n", nil
+			}
+		}
+		return "", nil
+	}
+	projects := []claude.ProjectInfo{
+		{Path: "/home/me/x", Aliases: []string{"/missing", "/srv/x"}},
+		{Path: "/home/me/y", Host: "laptop", GitBranch: "dev"},
+	}
+
+	enrichWithGit(git, projects)
+
+	x := projects[0]
+	if !x.GitDirty || x.UncommittedFiles != 2 || x.GitBranch != "main" {
+		t.Errorf("x = dirty %v, %d files on %q; want dirty, 2 files on main", x.GitDirty, x.UncommittedFiles, x.GitBranch)
+	}
+	if y := projects[1]; y.GitDirty || y.GitBranch != "dev" {
+		t.Errorf("remote project y changed: %+v", y)
+	}
+}
+
 func TestCategorize(t *testing.T) {
 	now := time.Now()
 
@@ -83,3 +119,24 @@ func TestScore(t *testing.T) {
 		t.Errorf("clean old project should score lower than dirty recent one: %f >= %f", cleanScore, score)
 	}
 }
+
+func TestCategorizeReminderDue(t *testing.T) {
+	now := time.Now()
+
+	projects := []claude.ProjectInfo{
+		{Path: "/p/dirty", ShortName: "dirty", GitDirty: true, LastActivity: now, DaysSinceActive: 0, PromptCount: 500},
+		{Path: "/p/parked", ShortName: "parked", GitBranch: "main", LastActivity: now.Add(-30 * 24 * time.Hour), DaysSinceActive: 30, PromptCount: 2, ReminderDue: true},
+	}
+
+	result := Categorize(projects, map[string]bool{"/p/parked": true})
+
+	if len(result.OpenWork) != 2 {
+		t.Fatalf("expected 2 open work items, got %d", len(result.OpenWork))
+	}
+	if result.OpenWork[0].Path != "/p/parked" {
+		t.Errorf("project with due reminder should be listed first, got %s", result.OpenWork[0].Path)
+	}
+	if len(result.Acknowledged) != 0 {
+		t.Errorf("due reminder should override acknowledgement, got %d acknowledged", len(result.Acknowledged))
+	}
+}
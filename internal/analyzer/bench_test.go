@@ -0,0 +1,44 @@
+package analyzer_test
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/config"
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel/fixture"
+)
+
+// BenchmarkRun runs the whole pipeline over generated data with real git
+// repositories, at each depth.
+func BenchmarkRun(b *testing.B) {
+	if _, err := exec.LookPath("git"); err != nil {
+		b.Skip("git not installed")
+	}
+	now := time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+	fx, err := fixture.Generate(b.TempDir(), fixture.Options{
+		Projects: 30, Prompts: 100, Sessions: 5, Messages: 100, Days: 30, Repos: true, Seed: 1, Now: now,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, depth := range []string{"quick", "medium", "deep"} {
+		b.Run(depth, func(b *testing.B) {
+			a := &analyzer.Analyzer{
+				Profiles: []analyzer.Profile{{Name: "default", FS: os.DirFS(fx.ClaudeDir)}},
+				Config:   &config.Config{},
+				Now:      func() time.Time { return now },
+				Depth:    depth,
+				Range:    analyzer.Range{Since: now.AddDate(0, 0, -30), Until: now.Add(time.Second)},
+			}
+			for b.Loop() {
+				if _, err := a.Run(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
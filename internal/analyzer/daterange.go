@@ -0,0 +1,86 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RangeExpressions lists the relative expressions accepted by ParseRange in
+// addition to YYYY-MM-DD dates.
+var RangeExpressions = []string{"today", "yesterday", "this-week", "last-week", "this-month", "last-month", "this-year", "last-year"}
+
+// Range is the span [Since, Until) an analysis covers. Projects are judged
+// as of Until, so a range ending in the past shows what was forgotten then.
+type Range struct {
+	Since time.Time
+	Until time.Time
+}
+
+// ParseRange resolves --since and --until expressions relative to now. Both
+// take a date (YYYY-MM-DD) or one of RangeExpressions: since selects the
+// start of the day or period, until its end, so "--since last-month --until
+// last-month" covers exactly the previous month. An empty until means now
+// and an empty since means days days before until. Until never lies in the
+// future.
+func ParseRange(since, until string, days int, now time.Time) (Range, error) {
+	r := Range{Until: now}
+	if until != "" {
+		_, end, err := parsePeriod(until, now)
+		if err != nil {
+			return Range{}, err
+		}
+		if end.Before(now) {
+			r.Until = end
+		}
+	}
+
+	r.Since = r.Until.AddDate(0, 0, -days)
+	if since != "" {
+		start, _, err := parsePeriod(since, now)
+		if err != nil {
+			return Range{}, err
+		}
+		r.Since = start
+	}
+
+	if !r.Since.Before(r.Until) {
+		return Range{}, fmt.Errorf("range starts %s, after it ends %s", r.Since.Format("2006-01-02"), r.Until.Format("2006-01-02"))
+	}
+	return r, nil
+}
+
+// parsePeriod returns the start and end of the day or period expr denotes.
+func parsePeriod(expr string, now time.Time) (start, end time.Time, err error) {
+	today := bucketStart(now, "day")
+	switch expr {
+	case "today":
+		return today, today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, nil
+	case "this-week", "last-week":
+		start = bucketStart(now, "week")
+		if expr == "last-week" {
+			start = start.AddDate(0, 0, -7)
+		}
+		return start, start.AddDate(0, 0, 7), nil
+	case "this-month", "last-month":
+		start = bucketStart(now, "month")
+		if expr == "last-month" {
+			start = start.AddDate(0, -1, 0)
+		}
+		return start, start.AddDate(0, 1, 0), nil
+	case "this-year", "last-year":
+		start = time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+		if expr == "last-year" {
+			start = start.AddDate(-1, 0, 0)
+		}
+		return start, start.AddDate(1, 0, 0), nil
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", expr, now.Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD or %s)", expr, strings.Join(RangeExpressions, ", "))
+	}
+	return day, day.AddDate(0, 0, 1), nil
+}
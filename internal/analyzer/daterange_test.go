@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	// A Wednesday afternoon
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		since, until string
+		days         int
+		want         Range
+	}{
+		{"", "", 14, Range{now.AddDate(0, 0, -14), now}},
+		{"2026-09-01", "2026-09-30", 14, Range{day(9, 1), day(10, 1)}},
+		{"2026-09-01", "", 14, Range{day(9, 1), now}},
+		{"", "2026-09-30", 7, Range{day(9, 24), day(10, 1)}},
+		{"last-week", "last-week", 14, Range{day(10, 5), day(10, 12)}},
+		{"this-week", "", 14, Range{day(10, 12), now}},
+		{"last-month", "last-month", 14, Range{day(9, 1), day(10, 1)}},
+		{"this-month", "this-month", 14, Range{day(10, 1), now}},
+		{"yesterday", "yesterday", 14, Range{day(10, 13), day(10, 14)}},
+		{"today", "", 14, Range{day(10, 14), now}},
+		{"last-year", "last-year", 14, Range{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRange(tt.since, tt.until, tt.days, now)
+		if err != nil {
+			t.Errorf("ParseRange(%q, %q): %v", tt.since, tt.until, err)
+			continue
+		}
+		if !got.Since.Equal(tt.want.Since) || !got.Until.Equal(tt.want.Until) {
+			t.Errorf("ParseRange(%q, %q) = %v - %v, want %v - %v", tt.since, tt.until, got.Since, got.Until, tt.want.Since, tt.want.Until)
+		}
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	for _, tt := range []struct{ since, until string }{
+		{"2026-13-01", ""},
+		{"", "next-week"},
+		{"2026-10-01", "2026-09-01"},
+		{"2026-11-01", ""},
+	} {
+		if _, err := ParseRange(tt.since, tt.until, 14, now); err == nil {
+			t.Errorf("ParseRange(%q, %q) succeeded, want error", tt.since, tt.until)
+		}
+	}
+}
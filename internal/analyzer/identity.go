@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"os"
+	"sort"
+
+	gitpkg "github.com/dkd-dobberkau/squirrel/internal/git"
+	"github.com/dkd-dobberkau/squirrel/internal/syncstore"
+)
+
+// CanonicalPaths maps recorded project paths to the logical path they should be
+// merged under. rewrite (may be nil) is applied first; afterwards paths whose
+// git identity matches, as selected by mergeBy ("remote", "root-commit"), are
+// folded onto a single path, preferring checkouts that exist on this machine.
+// Paths that don't exist here, e.g. from another machine's history, keep
+// their (rewritten) path; see canonicalPaths for merging them.
+func CanonicalPaths(paths []string, rewrite func(string) string, mergeBy []string) map[string]string {
+	return canonicalPaths(gitpkg.Exec, paths, rewrite, mergeBy, nil)
+}
+
+// canonicalPaths is CanonicalPaths with the git identities other machines
+// recorded for their checkouts, keyed by rewritten path. Paths that don't
+// exist here are merged by these; without one they keep their path.
+func canonicalPaths(git gitpkg.Runner, paths []string, rewrite func(string) string, mergeBy []string, known map[string]syncstore.GitIdentity) map[string]string {
+	canonical := make(map[string]string, len(paths))
+	for _, p := range paths {
+		if rewrite != nil {
+			canonical[p] = rewrite(p)
+		} else {
+			canonical[p] = p
+		}
+	}
+	if len(mergeBy) == 0 {
+		return canonical
+	}
+
+	var targets []string
+	seen := make(map[string]bool)
+	for _, t := range canonical {
+		if !seen[t] {
+			seen[t] = true
+			targets = append(targets, t)
+		}
+	}
+	exists := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		if info, err := os.Stat(t); err == nil && info.IsDir() {
+			exists[t] = true
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if exists[targets[i]] != exists[targets[j]] {
+			return exists[targets[i]]
+		}
+		return targets[i] < targets[j]
+	})
+
+	byIdentity := make(map[string]string)
+	resolved := make(map[string]string, len(targets))
+	for _, t := range targets {
+		resolved[t] = t
+		var id string
+		if exists[t] {
+			id = gitIdentity(git, t, mergeBy)
+		} else if k, ok := known[t]; ok {
+			id = knownIdentity(k, mergeBy)
+		}
+		if id == "" {
+			continue
+		}
+		if first, ok := byIdentity[id]; ok {
+			resolved[t] = first
+		} else {
+			byIdentity[id] = t
+		}
+	}
+
+	for p, t := range canonical {
+		canonical[p] = resolved[t]
+	}
+	return canonical
+}
+
+func gitIdentity(git gitpkg.Runner, path string, mergeBy []string) string {
+	return identityKey(mergeBy, func() string { return git.RemoteURL(path) }, func() string { return git.RootCommit(path) })
+}
+
+func knownIdentity(id syncstore.GitIdentity, mergeBy []string) string {
+	return identityKey(mergeBy, func() string { return id.Remote }, func() string { return id.RootCommit })
+}
+
+// identityKey returns the first identity in mergeBy order that is known,
+// looking each up only when it is needed.
+func identityKey(mergeBy []string, remote, rootCommit func() string) string {
+	for _, m := range mergeBy {
+		switch m {
+		case "remote":
+			if url := remote(); url != "" {
+				return "remote:" + url
+			}
+		case "root-commit":
+			if hash := rootCommit(); hash != "" {
+				return "root:" + hash
+			}
+		}
+	}
+	return ""
+}
+
+// gitIdentities reads the git identities of the given paths that are
+// repositories on this machine.
+func gitIdentities(git gitpkg.Runner, paths []string) map[string]syncstore.GitIdentity {
+	ids := make(map[string]syncstore.GitIdentity)
+	for _, p := range paths {
+		id := syncstore.GitIdentity{Remote: git.RemoteURL(p), RootCommit: git.RootCommit(p)}
+		if id != (syncstore.GitIdentity{}) {
+			ids[p] = id
+		}
+	}
+	return ids
+}
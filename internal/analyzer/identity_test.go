@@ -0,0 +1,102 @@
+package analyzer
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dkd-dobberkau/squirrel/internal/syncstore"
+)
+
+func TestCanonicalPathsRewrite(t *testing.T) {
+	rewrite := func(p string) string {
+		return strings.Replace(p, "/Users/me", "/home/me", 1)
+	}
+
+	canonical := CanonicalPaths([]string{"/Users/me/src/x", "/home/me/src/x", "/opt/y"}, rewrite, nil)
+
+	if canonical["/Users/me/src/x"] != "/home/me/src/x" {
+		t.Errorf("expected rewritten path, got %q", canonical["/Users/me/src/x"])
+	}
+	if canonical["/opt/y"] != "/opt/y" {
+		t.Errorf("expected unchanged path, got %q", canonical["/opt/y"])
+	}
+}
+
+func TestCanonicalPathsMergeByRemote(t *testing.T) {
+	root := t.TempDir()
+	clone := func(name, remote string) string {
+		dir := filepath.Join(root, name)
+		os.MkdirAll(dir, 0755)
+		run := func(args ...string) {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			cmd.Run()
+		}
+		run("init")
+		run("remote", "add", "origin", remote)
+		return dir
+	}
+
+	a := clone("a", "git@github.com:team/app.git")
+	b := clone("b", "https://github.com/team/app")
+	c := clone("c", "git@github.com:team/other.git")
+
+	canonical := CanonicalPaths([]string{b, a, c}, nil, []string{"remote"})
+
+	if canonical[a] != a || canonical[b] != a {
+		t.Errorf("expected both clones merged onto %q, got a=%q b=%q", a, canonical[a], canonical[b])
+	}
+	if canonical[c] != c {
+		t.Errorf("expected unrelated repo to keep its path, got %q", canonical[c])
+	}
+}
+
+func TestCanonicalPathsMergeOtherMachines(t *testing.T) {
+	local := t.TempDir()
+	git := func(path string, args ...string) (string, error) {
+		if path != local {
+			return "", errors.New("no such directory")
+		}
+		if strings.Join(args, " ") == "config --get remote.origin.url" {
+			return "git@github.com:team/app.git\n", nil
+		}
+		return "", errors.New("no commits")
+	}
+	// Checkouts on other machines, known from their sync snapshots
+	known := map[string]syncstore.GitIdentity{
+		"/Users/me/src/app":    {Remote: "github.com/team/app"},
+		"/Users/me/src/lib":    {RootCommit: "c0ffee"},
+		"/Users/other/lib":     {Remote: "github.com/other/lib", RootCommit: "c0ffee"},
+		"/Users/me/src/unique": {Remote: "github.com/team/unique"},
+	}
+	paths := []string{local, "/Users/me/src/app", "/Users/me/src/lib", "/Users/other/lib", "/Users/me/src/unique", "/Users/me/src/unknown"}
+
+	canonical := canonicalPaths(git, paths, nil, []string{"remote", "root-commit"}, known)
+
+	if canonical["/Users/me/src/app"] != local {
+		t.Errorf("app = %q, want merged onto the local checkout %q", canonical["/Users/me/src/app"], local)
+	}
+	// Each path is identified by the first identity in mergeBy order it
+	// has, so the libs only share one by root commit
+	if canonical["/Users/me/src/lib"] != "/Users/me/src/lib" || canonical["/Users/other/lib"] != "/Users/other/lib" {
+		t.Errorf("lib = %q and %q, want separate: their remotes differ", canonical["/Users/me/src/lib"], canonical["/Users/other/lib"])
+	}
+	for _, p := range []string{"/Users/me/src/unique", "/Users/me/src/unknown"} {
+		if canonical[p] != p {
+			t.Errorf("%s = %q, want its own path", p, canonical[p])
+		}
+	}
+
+	canonical = canonicalPaths(git, paths, nil, []string{"root-commit"}, known)
+	if canonical["/Users/other/lib"] != "/Users/me/src/lib" {
+		t.Errorf("by root commit, other lib = %q, want merged onto /Users/me/src/lib", canonical["/Users/other/lib"])
+	}
+	canonical = canonicalPaths(git, paths, nil, []string{"remote"}, nil)
+	if canonical["/Users/me/src/app"] != "/Users/me/src/app" {
+		t.Errorf("without known identities app = %q, want its own path", canonical["/Users/me/src/app"])
+	}
+}
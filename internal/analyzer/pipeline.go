@@ -0,0 +1,396 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/internal/config"
+	gitpkg "github.com/dkd-dobberkau/squirrel/internal/git"
+	"github.com/dkd-dobberkau/squirrel/internal/syncstore"
+)
+
+// ErrNotFound is returned when no project matches a query.
+var ErrNotFound = errors.New("not found")
+
+// SparklineWidth is the number of slices in a project's Activity series,
+// drawn as its sparkline.
+const SparklineWidth = 14
+
+// Profile is one Claude data directory, e.g. a work and a personal setup.
+// FS is rooted at the directory, which holds history.jsonl and projects/.
+type Profile struct {
+	Name string
+	FS   fs.FS
+}
+
+// Analyzer runs the analysis pipeline: it reads the history and sessions of
+// its profiles, aggregates and merges projects, adds git state, annotations
+// and acknowledgements, and categorizes the result. The clock, the Claude
+// data and git are only reached through its fields, so a run can be
+// reproduced in tests.
+type Analyzer struct {
+	Profiles []Profile
+	// Config supplies rewrites, merges, acknowledgements, notes and the
+	// ignore list; it must be set.
+	Config *config.Config
+	// Now returns the current time, which acknowledgement expiry and
+	// reminders are checked against; nil means time.Now.
+	Now func() time.Time
+	// Git runs git for repository status and identities; nil means the git
+	// binary.
+	Git gitpkg.Runner
+
+	// Depth is "quick", "medium" (adds git status) or "deep" (adds TODOs).
+	Depth string
+	// Range is the time range analysed.
+	Range Range
+	// AllHosts adds projects that only exist on other machines, read from
+	// the config's sync directory.
+	AllHosts bool
+	// IncludeIgnored fills CategorizedProjects.Ignored.
+	IncludeIgnored bool
+	// Tags and Groups keep only projects with any of the tags and in any of
+	// the groups.
+	Tags   []string
+	Groups []string
+}
+
+func (a *Analyzer) now() time.Time {
+	if a.Now != nil {
+		return a.Now()
+	}
+	return time.Now()
+}
+
+func (a *Analyzer) git() gitpkg.Runner {
+	if a.Git != nil {
+		return a.Git
+	}
+	return gitpkg.Exec
+}
+
+// History parses and concatenates the history of every profile.
+// owners maps each project path to the profiles it was used in; it is nil
+// when only one profile is read, so single-profile output stays unlabelled.
+func (a *Analyzer) History() (entries []claude.HistoryEntry, owners map[string][]string, err error) {
+	if len(a.Profiles) > 1 {
+		owners = make(map[string][]string)
+	}
+	for _, p := range a.Profiles {
+		e, err := claude.ParseHistoryFS(p.FS, "history.jsonl")
+		if err != nil {
+			if len(a.Profiles) > 1 {
+				return nil, nil, fmt.Errorf("reading history of profile %s: %w", p.Name, err)
+			}
+			return nil, nil, fmt.Errorf("reading history: %w", err)
+		}
+		if owners != nil {
+			for _, entry := range e {
+				if !slices.Contains(owners[entry.Project], p.Name) {
+					owners[entry.Project] = append(owners[entry.Project], p.Name)
+				}
+			}
+		}
+		entries = append(entries, e...)
+	}
+	return entries, owners, nil
+}
+
+// Aggregate groups the history entries in r into projects and folds aliased
+// paths together according to the config's rewrite and merge rules.
+func (a *Analyzer) Aggregate(entries []claude.HistoryEntry, r Range) []claude.ProjectInfo {
+	projects := claude.AggregateRange(entries, r.Since, r.Until)
+
+	paths := make([]string, len(projects))
+	for i, p := range projects {
+		paths[i] = p.Path
+	}
+	canonical := canonicalPaths(a.git(), paths, a.Config.RewritePath, a.Config.MergeBy, a.syncedIdentities())
+
+	return claude.MergeProjects(projects, canonical)
+}
+
+// syncedIdentities returns the git identities other machines recorded in
+// the sync directory, for merging their paths by mergeBy. An unreadable
+// sync directory only leaves those paths unmerged.
+func (a *Analyzer) syncedIdentities() map[string]syncstore.GitIdentity {
+	if len(a.Config.MergeBy) == 0 || a.Config.SyncDir == "" {
+		return nil
+	}
+	snapshots, err := syncstore.ReadAll(a.Config.SyncDir)
+	if err != nil {
+		return nil
+	}
+	return syncstore.Identities(snapshots, syncstore.Hostname(a.Config), a.Config.RewritePath)
+}
+
+// GitIdentities reads the git identities of the local projects' paths, for
+// other machines to merge theirs with; see syncstore.Snapshot.
+func (a *Analyzer) GitIdentities(projects []claude.ProjectInfo) map[string]syncstore.GitIdentity {
+	var paths []string
+	for _, p := range projects {
+		if p.Host == "" {
+			paths = append(paths, p.Paths()...)
+		}
+	}
+	return gitIdentities(a.git(), paths)
+}
+
+// Lookback returns the days days up to the end of the range, for lookups
+// that must reach further back than the range itself.
+func (a *Analyzer) Lookback(days int) Range {
+	return Range{Since: a.Range.Until.AddDate(0, 0, -days), Until: a.Range.Until}
+}
+
+// Enrich labels projects with their profiles and adds sessions and,
+// depending on the depth, git status.
+func (a *Analyzer) Enrich(projects []claude.ProjectInfo, owners map[string][]string) {
+	labelProfiles(projects, owners)
+	for _, p := range a.Profiles {
+		if sub, err := fs.Sub(p.FS, "projects"); err == nil {
+			claude.EnrichWithSessionsFS(projects, sub)
+		}
+	}
+	if a.Depth == "medium" || a.Depth == "deep" {
+		enrichWithGit(a.git(), projects)
+	}
+}
+
+// EnrichWithTodos extracts TODOs from the project's session files in every
+// profile.
+func (a *Analyzer) EnrichWithTodos(project *claude.ProjectInfo) {
+	for _, p := range a.Profiles {
+		if sub, err := fs.Sub(p.FS, "projects"); err == nil {
+			claude.EnrichWithTodosFS(project, sub)
+		}
+	}
+}
+
+// Projects returns the enriched projects active in the range, plus those
+// with a due reminder.
+func (a *Analyzer) Projects() ([]claude.ProjectInfo, error) {
+	entries, owners, err := a.History()
+	if err != nil {
+		return nil, err
+	}
+
+	projects := a.Aggregate(entries, a.Range)
+
+	// Projects with a due reminder resurface even if they fell out of the window
+	if a.Config.HasFiredReminders(a.now()) {
+		var dormant []claude.ProjectInfo
+		var paths [][]string
+		for _, p := range a.Aggregate(entries, a.Lookback(36500)) {
+			if !slices.ContainsFunc(projects, func(q claude.ProjectInfo) bool { return q.Path == p.Path }) {
+				dormant = append(dormant, p)
+				paths = append(paths, p.Paths())
+			}
+		}
+		due := a.Config.DueReminderPaths(a.now(), paths...)
+		for _, p := range dormant {
+			if slices.Contains(due, p.Path) {
+				projects = append(projects, p)
+			}
+		}
+	}
+
+	for i := range projects {
+		projects[i].Activity = claude.ActivitySeries(entries, projects[i].Paths(), a.Range.Since, a.Range.Until, SparklineWidth)
+	}
+
+	a.Enrich(projects, owners)
+	return projects, nil
+}
+
+// Resolve finds the project matching query within the year up to the end of
+// the range.
+func (a *Analyzer) Resolve(query string) (claude.ProjectInfo, error) {
+	entries, _, err := a.History()
+	if err != nil {
+		return claude.ProjectInfo{}, err
+	}
+	projects := a.Aggregate(entries, a.Lookback(365))
+	project, ok := claude.FindProject(projects, query)
+	if !ok {
+		return claude.ProjectInfo{}, fmt.Errorf("project %q %w", query, ErrNotFound)
+	}
+	return project, nil
+}
+
+// Run analyses the projects in the range and sorts them into categories.
+func (a *Analyzer) Run() (CategorizedProjects, error) {
+	projects, err := a.Projects()
+	if err != nil {
+		return CategorizedProjects{}, err
+	}
+
+	if a.AllHosts && a.Config.SyncDir != "" {
+		snapshots, err := syncstore.ReadAll(a.Config.SyncDir)
+		if err != nil {
+			return CategorizedProjects{}, fmt.Errorf("reading sync directory: %w", err)
+		}
+		remote := syncstore.RemoteProjects(snapshots, syncstore.Hostname(a.Config), projects, a.Config.RewritePath, a.Range.Since, a.Range.Until)
+		projects = append(projects, remote...)
+	}
+
+	for i := range projects {
+		a.Annotate(&projects[i])
+	}
+
+	projects, ignored := a.SplitIgnored(projects)
+
+	projects, err = a.filter(projects)
+	if err != nil {
+		return CategorizedProjects{}, err
+	}
+
+	ackedPaths := make(map[string]bool)
+	for i, p := range projects {
+		m := a.MatchAck(p)
+		if m.Acknowledged {
+			ackedPaths[p.Path] = true
+		}
+		projects[i].AckedBy = m.Rule
+		projects[i].WokenBy = m.WokenBy
+	}
+
+	categorized := Categorize(projects, ackedPaths)
+	categorized.Groups = a.presentGroups(projects)
+
+	if a.IncludeIgnored {
+		ignored, err = a.filter(ignored)
+		if err != nil {
+			return CategorizedProjects{}, err
+		}
+		categorized.Ignored = ignored
+	}
+
+	if a.Depth == "deep" {
+		for _, list := range [][]claude.ProjectInfo{categorized.OpenWork, categorized.RecentActivity, categorized.Sleeping} {
+			for i := range list {
+				a.EnrichWithTodos(&list[i])
+			}
+		}
+	}
+
+	return categorized, nil
+}
+
+// Annotate copies the user's tags, group and notes for a project from the config.
+func (a *Analyzer) Annotate(p *claude.ProjectInfo) {
+	paths := p.Paths()
+	p.Tags = a.Config.TagsFor(paths...)
+	p.Group = a.Config.GroupFor(paths...)
+
+	p.Notes = nil
+	for _, n := range a.Config.NotesFor(paths...) {
+		p.Notes = append(p.Notes, claude.Note{Text: n.Text, CreatedAt: n.CreatedAt, RemindAt: n.RemindAt})
+	}
+	_, p.ReminderDue = a.Config.DueReminder(a.now(), paths...)
+}
+
+// SplitIgnored separates projects on the config's ignore list from the rest.
+func (a *Analyzer) SplitIgnored(projects []claude.ProjectInfo) (kept, ignored []claude.ProjectInfo) {
+	for _, p := range projects {
+		if entry, ok := a.Config.IgnoredBy(p.Paths()...); ok {
+			p.IgnoredBy = entry
+			ignored = append(ignored, p)
+			continue
+		}
+		kept = append(kept, p)
+	}
+	return kept, ignored
+}
+
+// MatchAck checks the project's paths against explicit acks, their wake-up
+// conditions, and ack rules.
+func (a *Analyzer) MatchAck(p claude.ProjectInfo) config.AckMatch {
+	return a.Config.MatchAck(config.AckSubject{
+		Paths:       p.Paths(),
+		PromptCount: p.PromptCount,
+		IsGitRepo: func() bool {
+			return p.Host == "" && a.git().IsRepo(p.Path)
+		},
+		State: func() config.AckSnapshot {
+			return a.AckState(p)
+		},
+		Now: a.now(),
+	})
+}
+
+// AckState captures the project state that snooze wake-up conditions compare
+// against. Projects from other machines only report their activity.
+func (a *Analyzer) AckState(p claude.ProjectInfo) config.AckSnapshot {
+	state := config.AckSnapshot{LastActivity: p.LastActivity}
+	if p.Host != "" {
+		return state
+	}
+	git := a.git()
+	if status, err := git.CheckStatus(p.Path); err == nil && status.IsRepo {
+		state.Dirty = status.IsDirty
+		state.RemoteHeads = git.RemoteHeads(p.Path)
+		state.Branches = git.Branches(p.Path)
+	}
+	return state
+}
+
+// filter keeps only projects matching Tags and Groups. A project matches if
+// it has any of the requested tags and belongs to any of the requested
+// groups.
+func (a *Analyzer) filter(projects []claude.ProjectInfo) ([]claude.ProjectInfo, error) {
+	for _, g := range a.Groups {
+		if !a.Config.HasGroup(g) {
+			return nil, fmt.Errorf("unknown group %q", g)
+		}
+	}
+	if len(a.Tags) == 0 && len(a.Groups) == 0 {
+		return projects, nil
+	}
+
+	var filtered []claude.ProjectInfo
+	for _, p := range projects {
+		if len(a.Tags) > 0 && !slices.ContainsFunc(p.Tags, func(t string) bool {
+			return slices.Contains(a.Tags, t)
+		}) {
+			continue
+		}
+		if len(a.Groups) > 0 && !slices.Contains(a.Groups, p.Group) {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	return filtered, nil
+}
+
+// presentGroups returns the configured groups that have at least one project.
+func (a *Analyzer) presentGroups(projects []claude.ProjectInfo) []string {
+	var groups []string
+	for _, g := range a.Config.GroupNames() {
+		if slices.ContainsFunc(projects, func(p claude.ProjectInfo) bool { return p.Group == g }) {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// labelProfiles records which profiles each project was used in.
+func labelProfiles(projects []claude.ProjectInfo, owners map[string][]string) {
+	if owners == nil {
+		return
+	}
+	for i := range projects {
+		var names []string
+		for _, path := range projects[i].Paths() {
+			for _, name := range owners[path] {
+				if !slices.Contains(names, name) {
+					names = append(names, name)
+				}
+			}
+		}
+		projects[i].Profiles = names
+	}
+}
@@ -0,0 +1,107 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/config"
+)
+
+func TestAnalyzerRun(t *testing.T) {
+	now := time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+	prompt := func(project string, daysAgo int) string {
+		return fmt.Sprintf(`{"display":"work","timestamp":%d,"project":%q}`+"\n", now.AddDate(0, 0, -daysAgo).Add(-time.Hour).UnixMilli(), project)
+	}
+	work := fstest.MapFS{
+		"history.jsonl":                         {Data: []byte(prompt("/src/app", 1) + prompt("/src/old", 10))},
+		"projects/-src-app/sessions-index.json": {Data: []byte(`{"version":1,"entries":[{"sessionId":"s1","summary":"Login","modified":"2026-03-15T10:00:00Z"}]}`)},
+	}
+	private := fstest.MapFS{
+		"history.jsonl": {Data: []byte(prompt("/src/app", 2) + prompt("/src/blog", 0))},
+	}
+	git := func(path string, args ...string) (string, error) {
+		if path != "/src/app" {
+			return "", errors.New("not a repository")
+		}
+		switch strings.Join(args, " ") {
+		case "rev-parse --abbrev-ref HEAD":
+			return "feature/login\n", nil
+		case "status --porcelain":
+			return " M main.go\n", nil
+		}
+		return "", nil
+	}
+
+	cfg := &config.Config{}
+	cfg.Ack("/src/blog", nil)
+	a := &Analyzer{
+		Profiles: []Profile{{Name: "work", FS: work}, {Name: "private", FS: private}},
+		Config:   cfg,
+		Now:      func() time.Time { return now },
+		Git:      git,
+		Depth:    "medium",
+		Range:    Range{Since: now.AddDate(0, 0, -14), Until: now},
+	}
+
+	result, err := a.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.OpenWork) != 1 || len(result.Sleeping) != 1 || len(result.Acknowledged) != 1 {
+		t.Fatalf("got %d open, %d sleeping, %d acknowledged; want 1 each", len(result.OpenWork), len(result.Sleeping), len(result.Acknowledged))
+	}
+	app := result.OpenWork[0]
+	if app.Path != "/src/app" || app.PromptCount != 2 || !app.GitDirty || app.LatestSummary != "Login" {
+		t.Errorf("unexpected app %+v", app)
+	}
+	if strings.Join(app.Profiles, ",") != "work,private" {
+		t.Errorf("app profiles = %v, want [work private]", app.Profiles)
+	}
+	if result.Sleeping[0].Path != "/src/old" || result.Sleeping[0].DaysSinceActive != 10 {
+		t.Errorf("sleeping = %+v, want /src/old 10 days inactive", result.Sleeping[0])
+	}
+}
+
+func TestAnalyzerReminderAliases(t *testing.T) {
+	now := time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+	prompt := func(project string, daysAgo int) string {
+		return fmt.Sprintf(`{"display":"work","timestamp":%d,"project":%q}`+"\n", now.AddDate(0, 0, -daysAgo).UnixMilli(), project)
+	}
+	fsys := fstest.MapFS{
+		"history.jsonl": {Data: []byte(prompt("/Users/me/app", 41) + prompt("/home/me/app", 40) + prompt("/Users/me/lib", 41) + prompt("/home/me/lib", 40))},
+	}
+	fired := now.AddDate(0, 0, -1)
+	cfg := &config.Config{
+		PathRewrites: []config.PathRewrite{{From: "/Users/me", To: "/home/me"}},
+		Notes: []config.NoteEntry{
+			{Path: "/Users/me/app", Text: "resume", CreatedAt: now.AddDate(0, 0, -30), RemindAt: &fired},
+			{Path: "/Users/me/lib", Text: "resume", CreatedAt: now.AddDate(0, 0, -30), RemindAt: &fired},
+			{Path: "/home/me/lib", Text: "done", CreatedAt: now.Add(-time.Hour)},
+		},
+	}
+	a := &Analyzer{
+		Profiles: []Profile{{Name: "work", FS: fsys}},
+		Config:   cfg,
+		Now:      func() time.Time { return now },
+		Git:      func(string, ...string) (string, error) { return "", errors.New("not a repository") },
+		Depth:    "medium",
+		Range:    Range{Since: now.AddDate(0, 0, -14), Until: now},
+	}
+
+	result, err := a.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The reminder on the alias resurfaces app; lib's was dismissed by a
+	// newer note on its canonical path
+	if len(result.OpenWork) != 1 || result.OpenWork[0].Path != "/home/me/app" || !result.OpenWork[0].ReminderDue {
+		t.Errorf("openWork = %+v, want /home/me/app with a due reminder", result.OpenWork)
+	}
+	if n := len(result.RecentActivity) + len(result.Sleeping); n != 0 {
+		t.Errorf("%d other projects resurfaced, want none", n)
+	}
+}
@@ -0,0 +1,224 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+// Stats are productivity metrics over a time range.
+type Stats struct {
+	Since time.Time
+	Until time.Time
+	// Days is the number of calendar days in the range.
+	Days int
+
+	Prompts        int
+	PromptsPerDay  float64
+	PromptsPerWeek float64
+	ActiveDays     int
+	LongestStreak  Streak
+	// CurrentStreak counts consecutive active days up to Until; a streak
+	// that ended the day before Until still counts.
+	CurrentStreak int
+	// Hours counts prompts per hour of the day, local time.
+	Hours [24]int
+	// TopHours are the busiest hours of the day, busiest first.
+	TopHours []int
+
+	Sessions           int
+	AvgSessionMinutes  float64
+	AvgSessionMessages float64
+
+	// ProjectsPerWeek lists the number of distinct projects per week
+	// (starting Monday) in the range.
+	ProjectsPerWeek    []WeekCount
+	AvgProjectsPerWeek float64
+	// ContextSwitches counts consecutive prompts on the same day that went
+	// to different projects; SwitchRate is their share of all such pairs.
+	ContextSwitches int
+	SwitchRate      float64
+
+	// Sleeping counts projects without activity for more than three days,
+	// SleepingOpen those of them left with open work.
+	Sleeping     int
+	SleepingOpen int
+}
+
+// Streak is a run of consecutive active days.
+type Streak struct {
+	Days  int
+	Start time.Time
+	End   time.Time
+}
+
+// WeekCount is a count for the week starting at Start.
+type WeekCount struct {
+	Start time.Time
+	Count int
+}
+
+// topHours is the number of hours reported in Stats.TopHours.
+const topHours = 3
+
+// ComputeStats computes metrics from the prompts and sessions of the given
+// projects in [since, until). Entries for other paths are ignored, so the
+// caller's ignore list and filters apply.
+func ComputeStats(entries []claude.HistoryEntry, projects []claude.ProjectInfo, since, until time.Time) Stats {
+	s := Stats{Since: since, Until: until}
+	s.Days = calendarDays(since, until)
+
+	owner := make(map[string]int)
+	for i, p := range projects {
+		for _, path := range p.Paths() {
+			owner[path] = i
+		}
+	}
+
+	type prompt struct {
+		t       time.Time
+		project int
+	}
+	var prompts []prompt
+	for _, e := range entries {
+		i, ok := owner[e.Project]
+		if !ok {
+			continue
+		}
+		t := time.UnixMilli(e.Timestamp)
+		if t.Before(since) || !t.Before(until) {
+			continue
+		}
+		prompts = append(prompts, prompt{t, i})
+	}
+	sort.Slice(prompts, func(i, j int) bool { return prompts[i].t.Before(prompts[j].t) })
+
+	s.Prompts = len(prompts)
+	if s.Days > 0 {
+		s.PromptsPerDay = float64(s.Prompts) / float64(s.Days)
+		s.PromptsPerWeek = s.PromptsPerDay * 7
+	}
+
+	days := make(map[time.Time]bool)
+	weeks := make(map[time.Time]map[int]bool)
+	pairs := 0
+	for i, p := range prompts {
+		day := bucketStart(p.t, "day")
+		days[day] = true
+		s.Hours[p.t.Hour()]++
+
+		week := bucketStart(p.t, "week")
+		if weeks[week] == nil {
+			weeks[week] = make(map[int]bool)
+		}
+		weeks[week][p.project] = true
+
+		if i > 0 && bucketStart(prompts[i-1].t, "day").Equal(day) {
+			pairs++
+			if prompts[i-1].project != p.project {
+				s.ContextSwitches++
+			}
+		}
+	}
+	s.ActiveDays = len(days)
+	if pairs > 0 {
+		s.SwitchRate = float64(s.ContextSwitches) / float64(pairs)
+	}
+	s.LongestStreak, s.CurrentStreak = streaks(days, until)
+	s.TopHours = busiestHours(s.Hours)
+
+	for start := bucketStart(since, "week"); start.Before(until); start = start.AddDate(0, 0, 7) {
+		s.ProjectsPerWeek = append(s.ProjectsPerWeek, WeekCount{Start: start, Count: len(weeks[start])})
+	}
+	if len(s.ProjectsPerWeek) > 0 {
+		total := 0
+		for _, w := range s.ProjectsPerWeek {
+			total += w.Count
+		}
+		s.AvgProjectsPerWeek = float64(total) / float64(len(s.ProjectsPerWeek))
+	}
+
+	var minutes, messages float64
+	for _, p := range projects {
+		for _, sess := range p.Sessions {
+			created, err := time.Parse(time.RFC3339, sess.Created)
+			if err != nil || created.Before(since) || !created.Before(until) {
+				continue
+			}
+			s.Sessions++
+			messages += float64(sess.MsgCount)
+			if modified, err := time.Parse(time.RFC3339, sess.Modified); err == nil && modified.After(created) {
+				minutes += modified.Sub(created).Minutes()
+			}
+		}
+
+		if p.DaysSinceActive > 3 {
+			s.Sleeping++
+			if isOpenWork(p) {
+				s.SleepingOpen++
+			}
+		}
+	}
+	if s.Sessions > 0 {
+		s.AvgSessionMinutes = minutes / float64(s.Sessions)
+		s.AvgSessionMessages = messages / float64(s.Sessions)
+	}
+
+	return s
+}
+
+// calendarDays counts the days touched by [since, until).
+func calendarDays(since, until time.Time) int {
+	n := 0
+	for d := bucketStart(since, "day"); d.Before(until); d = d.AddDate(0, 0, 1) {
+		n++
+	}
+	return n
+}
+
+// streaks returns the longest run of consecutive active days and the run
+// ending on the day of until or the day before.
+func streaks(days map[time.Time]bool, until time.Time) (Streak, int) {
+	sorted := make([]time.Time, 0, len(days))
+	for d := range days {
+		sorted = append(sorted, d)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	var longest, run Streak
+	for _, d := range sorted {
+		if run.Days > 0 && run.End.AddDate(0, 0, 1).Equal(d) {
+			run.Days++
+			run.End = d
+		} else {
+			run = Streak{Days: 1, Start: d, End: d}
+		}
+		if run.Days > longest.Days {
+			longest = run
+		}
+	}
+
+	// until is exclusive, so the last day in range is the one before it
+	// unless until falls within a day.
+	last := bucketStart(until.Add(-time.Nanosecond), "day")
+	current := 0
+	if run.Days > 0 && (run.End.Equal(last) || run.End.AddDate(0, 0, 1).Equal(last)) {
+		current = run.Days
+	}
+	return longest, current
+}
+
+func busiestHours(hours [24]int) []int {
+	order := make([]int, 0, 24)
+	for h, n := range hours {
+		if n > 0 {
+			order = append(order, h)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool { return hours[order[i]] > hours[order[j]] })
+	if len(order) > topHours {
+		order = order[:topHours]
+	}
+	return order
+}
@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+func TestComputeStats(t *testing.T) {
+	at := func(day, hour, min int) int64 {
+		return time.Date(2026, 3, day, hour, min, 0, 0, time.Local).UnixMilli()
+	}
+	projects := []claude.ProjectInfo{
+		{Path: "/a", DaysSinceActive: 1, Sessions: []claude.SessionEntry{
+			{Created: time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local).Format(time.RFC3339), Modified: time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local).Format(time.RFC3339), MsgCount: 10},
+			{Created: time.Date(2026, 3, 4, 9, 0, 0, 0, time.Local).Format(time.RFC3339), Modified: time.Date(2026, 3, 4, 9, 30, 0, 0, time.Local).Format(time.RFC3339), MsgCount: 20},
+			{Created: time.Date(2026, 2, 1, 9, 0, 0, 0, time.Local).Format(time.RFC3339), MsgCount: 99}, // out of range
+		}},
+		{Path: "/b", DaysSinceActive: 10, GitDirty: true},
+		{Path: "/c", DaysSinceActive: 10, GitBranch: "main"},
+	}
+	entries := []claude.HistoryEntry{
+		{Project: "/a", Timestamp: at(2, 9, 0)},
+		{Project: "/b", Timestamp: at(2, 9, 10)}, // switch
+		{Project: "/b", Timestamp: at(2, 9, 20)},
+		{Project: "/a", Timestamp: at(3, 9, 0)}, // new day, no switch
+		{Project: "/a", Timestamp: at(4, 14, 0)},
+		{Project: "/c", Timestamp: at(9, 14, 0)}, // second week
+		{Project: "/x", Timestamp: at(3, 9, 0)},  // not a listed project
+		{Project: "/a", Timestamp: at(20, 9, 0)}, // after until
+	}
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	until := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+
+	s := ComputeStats(entries, projects, since, until)
+
+	if s.Days != 8 || s.Prompts != 6 || s.ActiveDays != 4 {
+		t.Errorf("days/prompts/active = %d/%d/%d, want 8/6/4", s.Days, s.Prompts, s.ActiveDays)
+	}
+	if s.PromptsPerDay != 0.75 || s.PromptsPerWeek != 5.25 {
+		t.Errorf("per day/week = %v/%v", s.PromptsPerDay, s.PromptsPerWeek)
+	}
+	if s.LongestStreak.Days != 3 || !s.LongestStreak.Start.Equal(since) {
+		t.Errorf("longest streak = %+v, want 3 days from Mar 2", s.LongestStreak)
+	}
+	if s.CurrentStreak != 1 {
+		t.Errorf("current streak = %d, want 1 (Mar 9)", s.CurrentStreak)
+	}
+	if len(s.TopHours) != 2 || s.TopHours[0] != 9 || s.TopHours[1] != 14 {
+		t.Errorf("top hours = %v, want [9 14]", s.TopHours)
+	}
+	if s.Sessions != 2 || s.AvgSessionMinutes != 45 || s.AvgSessionMessages != 15 {
+		t.Errorf("sessions = %d, avg %v min, %v msgs", s.Sessions, s.AvgSessionMinutes, s.AvgSessionMessages)
+	}
+	if len(s.ProjectsPerWeek) != 2 || s.ProjectsPerWeek[0].Count != 2 || s.ProjectsPerWeek[1].Count != 1 || s.AvgProjectsPerWeek != 1.5 {
+		t.Errorf("projects per week = %+v, avg %v", s.ProjectsPerWeek, s.AvgProjectsPerWeek)
+	}
+	if s.ContextSwitches != 1 || s.SwitchRate != 0.5 {
+		t.Errorf("switches = %d, rate %v, want 1 of 2 same-day pairs", s.ContextSwitches, s.SwitchRate)
+	}
+	if s.Sleeping != 2 || s.SleepingOpen != 1 {
+		t.Errorf("sleeping = %d, with open work %d, want 2 and 1", s.Sleeping, s.SleepingOpen)
+	}
+}
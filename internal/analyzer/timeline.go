@@ -0,0 +1,146 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+// TimelinePeriods lists the bucket sizes accepted by Timeline.
+var TimelinePeriods = []string{"day", "week", "month"}
+
+// TimelineBucket is one day, week or month of activity.
+type TimelineBucket struct {
+	Start time.Time
+	// End is the start of the next bucket.
+	End      time.Time
+	Prompts  int
+	Projects []TimelineProject
+}
+
+// TimelineProject is a project's activity within one bucket.
+type TimelineProject struct {
+	Project     claude.ProjectInfo
+	Prompts     int
+	FirstPrompt time.Time
+	LastPrompt  time.Time
+	// Sessions last modified within the bucket, newest first.
+	Sessions []claude.SessionEntry
+}
+
+// Timeline buckets the prompts of the given projects in [since, until) by
+// day, week (starting Monday) or month. Buckets are returned newest
+// first; projects within a bucket by their last prompt, newest first.
+// Entries are matched to projects by all of a project's paths, so merged
+// aliases count towards the project they were folded into.
+func Timeline(entries []claude.HistoryEntry, projects []claude.ProjectInfo, by string, since, until time.Time) ([]TimelineBucket, error) {
+	if !slices.Contains(TimelinePeriods, by) {
+		return nil, fmt.Errorf("invalid period %q (use %s)", by, strings.Join(TimelinePeriods, ", "))
+	}
+
+	owner := make(map[string]int)
+	for i, p := range projects {
+		for _, path := range p.Paths() {
+			owner[path] = i
+		}
+	}
+
+	type key struct {
+		start   time.Time
+		project int
+	}
+	active := make(map[key]*TimelineProject)
+	buckets := make(map[time.Time]*TimelineBucket)
+
+	for _, e := range entries {
+		i, ok := owner[e.Project]
+		if !ok {
+			continue
+		}
+		ts := time.UnixMilli(e.Timestamp)
+		if ts.Before(since) || !ts.Before(until) {
+			continue
+		}
+		start := bucketStart(ts, by)
+
+		b := buckets[start]
+		if b == nil {
+			b = &TimelineBucket{Start: start, End: bucketEnd(start, by)}
+			buckets[start] = b
+		}
+		b.Prompts++
+
+		tp := active[key{start, i}]
+		if tp == nil {
+			tp = &TimelineProject{Project: projects[i], FirstPrompt: ts, LastPrompt: ts}
+			active[key{start, i}] = tp
+		}
+		tp.Prompts++
+		if ts.Before(tp.FirstPrompt) {
+			tp.FirstPrompt = ts
+		}
+		if ts.After(tp.LastPrompt) {
+			tp.LastPrompt = ts
+		}
+	}
+
+	for k, tp := range active {
+		b := buckets[k.start]
+		tp.Sessions = sessionsBetween(tp.Project.Sessions, b.Start, b.End)
+		b.Projects = append(b.Projects, *tp)
+	}
+
+	result := make([]TimelineBucket, 0, len(buckets))
+	for _, b := range buckets {
+		sort.Slice(b.Projects, func(i, j int) bool {
+			if !b.Projects[i].LastPrompt.Equal(b.Projects[j].LastPrompt) {
+				return b.Projects[i].LastPrompt.After(b.Projects[j].LastPrompt)
+			}
+			return b.Projects[i].Project.Path < b.Projects[j].Project.Path
+		})
+		result = append(result, *b)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Start.After(result[j].Start) })
+	return result, nil
+}
+
+// bucketStart returns the start of the day, week or month containing t.
+func bucketStart(t time.Time, by string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch by {
+	case "week":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return day
+}
+
+func bucketEnd(start time.Time, by string) time.Time {
+	switch by {
+	case "week":
+		return start.AddDate(0, 0, 7)
+	case "month":
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// sessionsBetween returns the sessions last modified in [start, end),
+// newest first.
+func sessionsBetween(sessions []claude.SessionEntry, start, end time.Time) []claude.SessionEntry {
+	var out []claude.SessionEntry
+	for _, s := range sessions {
+		modified, err := time.Parse(time.RFC3339, s.Modified)
+		if err != nil || modified.Before(start) || !modified.Before(end) {
+			continue
+		}
+		out = append(out, s)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Modified > out[j].Modified })
+	return out
+}
@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+func TestTimeline(t *testing.T) {
+	at := func(day, hour int) int64 {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.Local).UnixMilli()
+	}
+	projects := []claude.ProjectInfo{
+		{Path: "/src/app", ShortName: "app", Aliases: []string{"/old/app"}, Sessions: []claude.SessionEntry{
+			{SessionID: "s1", Summary: "Login", Modified: time.Date(2026, 3, 3, 12, 0, 0, 0, time.Local).Format(time.RFC3339)},
+			{SessionID: "s2", Summary: "Older", Modified: time.Date(2026, 2, 20, 12, 0, 0, 0, time.Local).Format(time.RFC3339)},
+		}},
+		{Path: "/src/lib", ShortName: "lib"},
+	}
+	entries := []claude.HistoryEntry{
+		{Project: "/src/app", Timestamp: at(2, 9)},  // Monday
+		{Project: "/old/app", Timestamp: at(3, 10)}, // alias of app
+		{Project: "/src/lib", Timestamp: at(4, 11)},
+		{Project: "/src/lib", Timestamp: at(10, 8)},   // next week
+		{Project: "/elsewhere", Timestamp: at(4, 12)}, // not a listed project
+		{Project: "/src/app", Timestamp: at(1, 12)},   // before since
+		{Project: "/src/app", Timestamp: at(16, 9)},   // after until
+	}
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	until := time.Date(2026, 3, 16, 0, 0, 0, 0, time.Local)
+
+	buckets, err := Timeline(entries, projects, "week", since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 2 {
+		t.Fatalf("got %d buckets, want 2", len(buckets))
+	}
+	if !buckets[0].Start.Equal(time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("newest bucket starts %v, want Monday Mar 9", buckets[0].Start)
+	}
+
+	week := buckets[1]
+	if week.Prompts != 3 || len(week.Projects) != 2 {
+		t.Fatalf("first week = %d prompts in %d projects, want 3 in 2", week.Prompts, len(week.Projects))
+	}
+	if week.Projects[0].Project.Path != "/src/lib" {
+		t.Errorf("projects not ordered by last prompt: %s first", week.Projects[0].Project.Path)
+	}
+	app := week.Projects[1]
+	if app.Prompts != 2 {
+		t.Errorf("app prompts = %d, want 2 including its alias", app.Prompts)
+	}
+	if len(app.Sessions) != 1 || app.Sessions[0].SessionID != "s1" {
+		t.Errorf("app sessions = %+v, want only s1", app.Sessions)
+	}
+
+	days, _ := Timeline(entries, projects, "day", since, until)
+	if len(days) != 4 {
+		t.Errorf("got %d day buckets, want 4", len(days))
+	}
+	months, _ := Timeline(entries, projects, "month", since, until)
+	if len(months) != 1 || months[0].Prompts != 4 {
+		t.Errorf("month buckets = %+v", months)
+	}
+
+	if _, err := Timeline(entries, projects, "year", since, until); err == nil {
+		t.Error("expected error for unknown period")
+	}
+}
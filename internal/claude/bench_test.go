@@ -0,0 +1,86 @@
+package claude_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel/fixture"
+)
+
+var benchNow = time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+
+// benchSizes are the data sets benchmarks run against, by total prompts.
+var benchSizes = []fixture.Options{
+	{Projects: 10, Prompts: 100, Sessions: 5, Messages: 50},
+	{Projects: 50, Prompts: 200, Sessions: 10, Messages: 100},
+	{Projects: 200, Prompts: 500, Sessions: 10, Messages: 100},
+}
+
+func generate(b *testing.B, opts fixture.Options) (*fixture.Fixture, []claude.HistoryEntry) {
+	b.Helper()
+	opts.Days, opts.Seed, opts.Now = 90, 1, benchNow
+	fx, err := fixture.Generate(b.TempDir(), opts)
+	if err != nil {
+		b.Fatal(err)
+	}
+	entries, err := claude.ParseHistory(filepath.Join(fx.ClaudeDir, "history.jsonl"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return fx, entries
+}
+
+func sizeName(opts fixture.Options) string {
+	return fmt.Sprintf("projects=%d/prompts=%d", opts.Projects, opts.Projects*opts.Prompts)
+}
+
+func BenchmarkParseHistory(b *testing.B) {
+	for _, opts := range benchSizes {
+		b.Run(sizeName(opts), func(b *testing.B) {
+			opts.Sessions = 0
+			fx, _ := generate(b, opts)
+			path := filepath.Join(fx.ClaudeDir, "history.jsonl")
+			for b.Loop() {
+				if _, err := claude.ParseHistory(path); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEnrichWithSessions(b *testing.B) {
+	for _, opts := range benchSizes {
+		b.Run(sizeName(opts), func(b *testing.B) {
+			fx, entries := generate(b, opts)
+			projects := claude.AggregateRange(entries, benchNow.AddDate(0, 0, -90), benchNow.Add(time.Second))
+			dir := filepath.Join(fx.ClaudeDir, "projects")
+			for b.Loop() {
+				claude.EnrichWithSessions(slices.Clone(projects), dir)
+			}
+		})
+	}
+}
+
+// BenchmarkEnrichWithTodos measures deep mode, which reads every session
+// file of a project. It skips the largest size, which takes seconds per
+// run.
+func BenchmarkEnrichWithTodos(b *testing.B) {
+	for _, opts := range benchSizes[:2] {
+		b.Run(sizeName(opts), func(b *testing.B) {
+			fx, entries := generate(b, opts)
+			projects := claude.AggregateRange(entries, benchNow.AddDate(0, 0, -90), benchNow.Add(time.Second))
+			dir := filepath.Join(fx.ClaudeDir, "projects")
+			claude.EnrichWithSessions(projects, dir)
+			for b.Loop() {
+				for _, p := range projects {
+					claude.EnrichWithTodos(&p, dir)
+				}
+			}
+		})
+	}
+}
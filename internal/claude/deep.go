@@ -3,6 +3,7 @@ package claude
 import (
 	"bufio"
 	"encoding/json"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -15,7 +16,11 @@ var checkboxPattern = regexp.MustCompile(`- \[ \]\s+(.+)`)
 // ParseSessionMessages reads a session JSONL file, keeping only the last maxLines lines
 // via a ring buffer to avoid loading huge files entirely into memory.
 func ParseSessionMessages(path string, maxLines int) ([]SessionMessage, error) {
-	f, err := os.Open(path)
+	return parseSessionMessages(os.DirFS(filepath.Dir(path)), filepath.Base(path), maxLines)
+}
+
+func parseSessionMessages(fsys fs.FS, name string, maxLines int) ([]SessionMessage, error) {
+	f, err := fsys.Open(name)
 	if err != nil {
 		return nil, err
 	}
@@ -138,12 +143,22 @@ func ExtractTodos(messages []SessionMessage, sessionID string) []TodoItem {
 
 // EnrichWithTodos reads session JSONL files for a single project and extracts TODOs.
 func EnrichWithTodos(project *ProjectInfo, claudeProjectsDir string) {
-	dirName := projectPathToDir(project.Path)
-	sessionsDir := filepath.Join(claudeProjectsDir, dirName)
+	EnrichWithTodosFS(project, os.DirFS(claudeProjectsDir))
+}
 
+// EnrichWithTodosFS is like EnrichWithTodos, reading the session files from
+// fsys, the Claude projects directory.
+func EnrichWithTodosFS(project *ProjectInfo, fsys fs.FS) {
 	for _, session := range project.Sessions {
-		jsonlPath := filepath.Join(sessionsDir, session.SessionID+".jsonl")
-		msgs, err := ParseSessionMessages(jsonlPath, 200)
+		// The session file lives under whichever of the project's paths recorded it
+		var msgs []SessionMessage
+		var err error
+		for _, path := range project.Paths() {
+			name := projectPathToDir(path) + "/" + session.SessionID + ".jsonl"
+			if msgs, err = parseSessionMessages(fsys, name, 200); err == nil {
+				break
+			}
+		}
 		if err != nil {
 			continue
 		}
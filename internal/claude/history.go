@@ -3,6 +3,7 @@ package claude
 import (
 	"bufio"
 	"encoding/json"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
@@ -11,7 +12,12 @@ import (
 
 // ParseHistory reads ~/.claude/history.jsonl and returns all entries.
 func ParseHistory(path string) ([]HistoryEntry, error) {
-	f, err := os.Open(path)
+	return ParseHistoryFS(os.DirFS(filepath.Dir(path)), filepath.Base(path))
+}
+
+// ParseHistoryFS is like ParseHistory, reading the file name from fsys.
+func ParseHistoryFS(fsys fs.FS, name string) ([]HistoryEntry, error) {
+	f, err := fsys.Open(name)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +39,13 @@ func ParseHistory(path string) ([]HistoryEntry, error) {
 // AggregateByProject groups history entries by project and computes per-project stats.
 // Only includes projects with activity in the last `days` days.
 func AggregateByProject(entries []HistoryEntry, days int) []ProjectInfo {
-	cutoff := time.Now().AddDate(0, 0, -days)
+	now := time.Now()
+	return AggregateRange(entries, now.AddDate(0, 0, -days), now)
+}
+
+// AggregateRange is like AggregateByProject for the entries in [since, until).
+// DaysSinceActive counts from until, so the projects look as they did then.
+func AggregateRange(entries []HistoryEntry, since, until time.Time) []ProjectInfo {
 
 	type projectAcc struct {
 		count      int
@@ -46,7 +58,7 @@ func AggregateByProject(entries []HistoryEntry, days int) []ProjectInfo {
 
 	for _, e := range entries {
 		t := time.UnixMilli(e.Timestamp)
-		if t.Before(cutoff) {
+		if t.Before(since) || !t.Before(until) {
 			continue
 		}
 
@@ -74,7 +86,7 @@ func AggregateByProject(entries []HistoryEntry, days int) []ProjectInfo {
 			LastActivity:    time.UnixMilli(p.lastTS),
 			FirstActivity:   time.UnixMilli(p.firstTS),
 			LastPrompt:      p.lastPrompt,
-			DaysSinceActive: int(time.Since(time.UnixMilli(p.lastTS)).Hours() / 24),
+			DaysSinceActive: int(until.Sub(time.UnixMilli(p.lastTS)).Hours() / 24),
 		})
 	}
 
@@ -84,9 +96,20 @@ func AggregateByProject(entries []HistoryEntry, days int) []ProjectInfo {
 // PromptsForProject filters history entries for a specific project path,
 // sorted by timestamp descending, limited to max entries.
 func PromptsForProject(entries []HistoryEntry, path string, max int) []HistoryEntry {
+	return PromptsForPaths(entries, []string{path}, max)
+}
+
+// PromptsForPaths is like PromptsForProject but matches any of the given paths,
+// e.g. a merged project's canonical path and its aliases.
+func PromptsForPaths(entries []HistoryEntry, paths []string, max int) []HistoryEntry {
+	want := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		want[p] = true
+	}
+
 	var filtered []HistoryEntry
 	for _, e := range entries {
-		if e.Project == path {
+		if want[e.Project] {
 			filtered = append(filtered, e)
 		}
 	}
@@ -101,3 +124,46 @@ func PromptsForProject(entries []HistoryEntry, path string, max int) []HistoryEn
 
 	return filtered
 }
+
+// DailyActivity counts prompts per local calendar day ("2006-01-02") for
+// any of the given paths.
+func DailyActivity(entries []HistoryEntry, paths []string) map[string]int {
+	want := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		want[p] = true
+	}
+
+	counts := make(map[string]int)
+	for _, e := range entries {
+		if want[e.Project] {
+			counts[time.UnixMilli(e.Timestamp).Format("2006-01-02")]++
+		}
+	}
+	return counts
+}
+
+// ActivitySeries splits [start, end) into n equal slices and counts the
+// prompts for any of the given paths in each, oldest first.
+func ActivitySeries(entries []HistoryEntry, paths []string, start, end time.Time, n int) []int {
+	if n <= 0 || !end.After(start) {
+		return nil
+	}
+	want := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		want[p] = true
+	}
+
+	series := make([]int, n)
+	span := end.Sub(start)
+	for _, e := range entries {
+		if !want[e.Project] {
+			continue
+		}
+		t := time.UnixMilli(e.Timestamp)
+		if t.Before(start) || !t.Before(end) {
+			continue
+		}
+		series[int(int64(t.Sub(start))*int64(n)/int64(span))]++
+	}
+	return series
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 )
@@ -132,6 +133,29 @@ func TestAggregateByProjectFiltersOldEntries(t *testing.T) {
 	}
 }
 
+func TestAggregateRange(t *testing.T) {
+	until := time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)
+	entries := []HistoryEntry{
+		{Display: "before", Timestamp: until.AddDate(0, 0, -40).UnixMilli(), Project: "/Users/test/old"},
+		{Display: "early", Timestamp: until.AddDate(0, 0, -20).UnixMilli(), Project: "/Users/test/app"},
+		{Display: "late", Timestamp: until.AddDate(0, 0, -5).UnixMilli(), Project: "/Users/test/app"},
+		{Display: "after", Timestamp: until.AddDate(0, 0, 3).UnixMilli(), Project: "/Users/test/app"},
+	}
+
+	projects := AggregateRange(entries, until.AddDate(0, 0, -30), until)
+
+	if len(projects) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(projects))
+	}
+	p := projects[0]
+	if p.PromptCount != 2 || p.LastPrompt != "late" {
+		t.Errorf("got %d prompts, last %q; want 2, \"late\"", p.PromptCount, p.LastPrompt)
+	}
+	if p.DaysSinceActive != 5 {
+		t.Errorf("DaysSinceActive = %d, want 5 (relative to until)", p.DaysSinceActive)
+	}
+}
+
 func TestPromptsForProject(t *testing.T) {
 	entries := []HistoryEntry{
 		{Display: "a1", Timestamp: 1000, Project: "/Users/test/project-a"},
@@ -220,3 +244,39 @@ func TestParseHistoryRoundTrip(t *testing.T) {
 		t.Errorf("expected short name 'myapp', got %q", projects[0].ShortName)
 	}
 }
+
+func TestDailyActivity(t *testing.T) {
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	entries := []HistoryEntry{
+		{Project: "/a", Timestamp: day.UnixMilli()},
+		{Project: "/a-alias", Timestamp: day.Add(2 * time.Hour).UnixMilli()},
+		{Project: "/a", Timestamp: day.AddDate(0, 0, 1).UnixMilli()},
+		{Project: "/b", Timestamp: day.UnixMilli()},
+	}
+
+	got := DailyActivity(entries, []string{"/a", "/a-alias"})
+	if got["2026-03-02"] != 2 || got["2026-03-03"] != 1 || len(got) != 2 {
+		t.Errorf("DailyActivity = %v", got)
+	}
+}
+
+func TestActivitySeries(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 4)
+	entries := []HistoryEntry{
+		{Project: "/a", Timestamp: start.UnixMilli()},
+		{Project: "/a", Timestamp: start.Add(30 * time.Hour).UnixMilli()},
+		{Project: "/a", Timestamp: start.Add(40 * time.Hour).UnixMilli()},
+		{Project: "/a", Timestamp: end.UnixMilli()}, // outside
+		{Project: "/b", Timestamp: start.UnixMilli()},
+	}
+
+	got := ActivitySeries(entries, []string{"/a"}, start, end, 2)
+	if len(got) != 2 || got[0] != 3 || got[1] != 0 {
+		t.Errorf("ActivitySeries(2) = %v, want [3 0]", got)
+	}
+	got = ActivitySeries(entries, []string{"/a"}, start, end, 4)
+	if want := []int{1, 2, 0, 0}; !slices.Equal(got, want) {
+		t.Errorf("ActivitySeries(4) = %v, want %v", got, want)
+	}
+}
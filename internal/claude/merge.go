@@ -0,0 +1,65 @@
+package claude
+
+import (
+	"path/filepath"
+	"slices"
+)
+
+// MergeProjects folds projects that map to the same canonical path into one
+// logical project. Prompt counts and sessions are combined and the activity
+// window is widened to cover all members. Recorded paths that differ from the
+// canonical one are kept in Aliases so their session data can still be found.
+// Projects missing from canonical keep their own path.
+func MergeProjects(projects []ProjectInfo, canonical map[string]string) []ProjectInfo {
+	index := make(map[string]int, len(projects))
+	merged := make([]ProjectInfo, 0, len(projects))
+
+	for _, p := range projects {
+		target := canonical[p.Path]
+		if target == "" {
+			target = p.Path
+		}
+
+		recorded := p.Paths()
+
+		i, ok := index[target]
+		if !ok {
+			p.Path = target
+			p.ShortName = filepath.Base(target)
+			p.Aliases = nil
+			for _, path := range recorded {
+				p.Aliases = appendAlias(p.Aliases, target, path)
+			}
+			index[target] = len(merged)
+			merged = append(merged, p)
+			continue
+		}
+
+		m := &merged[i]
+		for _, path := range recorded {
+			m.Aliases = appendAlias(m.Aliases, target, path)
+		}
+		m.PromptCount += p.PromptCount
+		if p.LastActivity.After(m.LastActivity) {
+			m.LastActivity = p.LastActivity
+			m.LastPrompt = p.LastPrompt
+			m.DaysSinceActive = p.DaysSinceActive
+		}
+		if !p.FirstActivity.IsZero() && (m.FirstActivity.IsZero() || p.FirstActivity.Before(m.FirstActivity)) {
+			m.FirstActivity = p.FirstActivity
+		}
+		if len(p.Sessions) > 0 {
+			m.Sessions = append(m.Sessions, p.Sessions...)
+			applyLatestSession(m)
+		}
+	}
+
+	return merged
+}
+
+func appendAlias(aliases []string, canonical, path string) []string {
+	if path == canonical || slices.Contains(aliases, path) {
+		return aliases
+	}
+	return append(aliases, path)
+}
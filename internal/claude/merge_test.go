@@ -0,0 +1,67 @@
+package claude
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeProjects(t *testing.T) {
+	now := time.Now()
+
+	projects := []ProjectInfo{
+		{Path: "/Users/me/src/x", ShortName: "x", PromptCount: 10, LastActivity: now.Add(-2 * time.Hour), FirstActivity: now.Add(-48 * time.Hour), LastPrompt: "mac", DaysSinceActive: 0},
+		{Path: "/home/me/src/x", ShortName: "x", PromptCount: 5, LastActivity: now.Add(-1 * time.Hour), FirstActivity: now.Add(-24 * time.Hour), LastPrompt: "linux", DaysSinceActive: 0},
+		{Path: "/home/me/src/y", ShortName: "y", PromptCount: 3, LastActivity: now},
+	}
+
+	canonical := map[string]string{
+		"/Users/me/src/x": "/home/me/src/x",
+		"/home/me/src/x":  "/home/me/src/x",
+	}
+
+	merged := MergeProjects(projects, canonical)
+
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 projects after merge, got %d", len(merged))
+	}
+
+	x := merged[0]
+	if x.Path != "/home/me/src/x" {
+		t.Errorf("expected canonical path, got %q", x.Path)
+	}
+	if x.PromptCount != 15 {
+		t.Errorf("expected combined prompt count 15, got %d", x.PromptCount)
+	}
+	if x.LastPrompt != "linux" {
+		t.Errorf("expected last prompt from most recent member, got %q", x.LastPrompt)
+	}
+	if !x.FirstActivity.Equal(now.Add(-48 * time.Hour)) {
+		t.Errorf("expected earliest first activity, got %v", x.FirstActivity)
+	}
+	if len(x.Aliases) != 1 || x.Aliases[0] != "/Users/me/src/x" {
+		t.Errorf("expected alias /Users/me/src/x, got %v", x.Aliases)
+	}
+
+	if merged[1].Path != "/home/me/src/y" || len(merged[1].Aliases) != 0 {
+		t.Errorf("unmapped project should be unchanged, got %+v", merged[1])
+	}
+}
+
+func TestMergeProjectsCombinesSessions(t *testing.T) {
+	projects := []ProjectInfo{
+		{Path: "/a/x", Sessions: []SessionEntry{{SessionID: "1", Summary: "old", Modified: "2026-02-19T12:00:00.000Z"}}},
+		{Path: "/b/x", Sessions: []SessionEntry{{SessionID: "2", Summary: "new", Modified: "2026-02-20T12:00:00.000Z"}}},
+	}
+
+	merged := MergeProjects(projects, map[string]string{"/b/x": "/a/x"})
+
+	if len(merged) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(merged))
+	}
+	if len(merged[0].Sessions) != 2 {
+		t.Errorf("expected 2 combined sessions, got %d", len(merged[0].Sessions))
+	}
+	if merged[0].LatestSummary != "new" {
+		t.Errorf("expected latest summary 'new', got %q", merged[0].LatestSummary)
+	}
+}
@@ -2,6 +2,7 @@ package claude
 
 import (
 	"encoding/json"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,7 +10,11 @@ import (
 
 // ParseSessionsIndex reads a sessions-index.json file.
 func ParseSessionsIndex(path string) (*SessionsIndex, error) {
-	data, err := os.ReadFile(path)
+	return parseSessionsIndex(os.DirFS(filepath.Dir(path)), filepath.Base(path))
+}
+
+func parseSessionsIndex(fsys fs.FS, name string) (*SessionsIndex, error) {
+	data, err := fs.ReadFile(fsys, name)
 	if err != nil {
 		return nil, err
 	}
@@ -28,25 +33,36 @@ func projectPathToDir(projectPath string) string {
 
 // EnrichWithSessions adds session data to ProjectInfo entries by reading
 // the corresponding sessions-index.json files from claudeProjectsDir.
+// Sessions recorded under any of a project's aliased paths are included.
 func EnrichWithSessions(projects []ProjectInfo, claudeProjectsDir string) {
+	EnrichWithSessionsFS(projects, os.DirFS(claudeProjectsDir))
+}
+
+// EnrichWithSessionsFS is like EnrichWithSessions, reading the session
+// indexes from fsys, the Claude projects directory.
+func EnrichWithSessionsFS(projects []ProjectInfo, fsys fs.FS) {
 	for i := range projects {
-		dirName := projectPathToDir(projects[i].Path)
-		idxPath := filepath.Join(claudeProjectsDir, dirName, "sessions-index.json")
+		for _, path := range projects[i].Paths() {
+			idx, err := parseSessionsIndex(fsys, projectPathToDir(path)+"/sessions-index.json")
+			if err != nil {
+				continue
+			}
 
-		idx, err := ParseSessionsIndex(idxPath)
-		if err != nil {
-			continue
+			projects[i].Sessions = append(projects[i].Sessions, idx.Entries...)
 		}
+		applyLatestSession(&projects[i])
+	}
+}
 
-		projects[i].Sessions = idx.Entries
-
-		var latestModified string
-		for _, s := range idx.Entries {
-			if s.Modified > latestModified {
-				latestModified = s.Modified
-				projects[i].LatestSummary = s.Summary
-				projects[i].LatestBranch = s.GitBranch
-			}
+// applyLatestSession copies summary and branch of the most recently modified
+// session onto the project.
+func applyLatestSession(p *ProjectInfo) {
+	var latestModified string
+	for _, s := range p.Sessions {
+		if s.Modified > latestModified {
+			latestModified = s.Modified
+			p.LatestSummary = s.Summary
+			p.LatestBranch = s.GitBranch
 		}
 	}
 }
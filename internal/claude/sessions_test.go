@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 )
 
 func TestParseSessionsIndex(t *testing.T) {
@@ -93,3 +94,50 @@ func TestEnrichWithSessions(t *testing.T) {
 		t.Errorf("expected branch 'feature/x', got %q", projects[0].LatestBranch)
 	}
 }
+
+func TestEnrichWithSessionsIncludesAliases(t *testing.T) {
+	claudeDir := t.TempDir()
+
+	write := func(dirName string, entries ...SessionEntry) {
+		projDir := filepath.Join(claudeDir, dirName)
+		os.MkdirAll(projDir, 0755)
+		data, _ := json.Marshal(SessionsIndex{Version: 1, Entries: entries})
+		os.WriteFile(filepath.Join(projDir, "sessions-index.json"), data, 0644)
+	}
+	write("-home-me-src-x", SessionEntry{SessionID: "linux", Summary: "On Linux", Modified: "2026-02-19T12:00:00.000Z"})
+	write("-Users-me-src-x", SessionEntry{SessionID: "mac", Summary: "On Mac", Modified: "2026-02-20T12:00:00.000Z"})
+
+	projects := []ProjectInfo{
+		{Path: "/home/me/src/x", ShortName: "x", Aliases: []string{"/Users/me/src/x"}},
+	}
+
+	EnrichWithSessions(projects, claudeDir)
+
+	if len(projects[0].Sessions) != 2 {
+		t.Fatalf("expected 2 sessions across aliases, got %d", len(projects[0].Sessions))
+	}
+	if projects[0].LatestSummary != "On Mac" {
+		t.Errorf("expected latest summary from alias, got %q", projects[0].LatestSummary)
+	}
+}
+
+func TestEnrichWithSessionsFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"-src-app/sessions-index.json": {Data: []byte(`{"version":1,"entries":[{"sessionId":"s1","summary":"Login","modified":"2026-03-01T10:00:00Z","gitBranch":"feature/login"}]}`)},
+		"-old-app/sessions-index.json": {Data: []byte(`{"version":1,"entries":[{"sessionId":"s0","summary":"Old","modified":"2026-02-01T10:00:00Z"}]}`)},
+		"-src-app/s1.jsonl":            {Data: []byte(`{"type":"user","message":{"role":"user","content":"TODO: write tests"}}` + "\n")},
+	}
+	projects := []ProjectInfo{{Path: "/src/app", Aliases: []string{"/old/app"}}}
+
+	EnrichWithSessionsFS(projects, fsys)
+
+	p := projects[0]
+	if len(p.Sessions) != 2 || p.LatestSummary != "Login" || p.LatestBranch != "feature/login" {
+		t.Fatalf("unexpected sessions %+v (summary %q, branch %q)", p.Sessions, p.LatestSummary, p.LatestBranch)
+	}
+
+	EnrichWithTodosFS(&p, fsys)
+	if len(p.Todos) != 1 || p.Todos[0].Text != "write tests" {
+		t.Errorf("todos = %+v, want one \"write tests\"", p.Todos)
+	}
+}
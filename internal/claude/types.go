@@ -67,15 +67,33 @@ type TodoItem struct {
 	Timestamp string `json:"timestamp"`
 }
 
+// Note is a user-written breadcrumb attached to a project
+type Note struct {
+	Text      string     `json:"text"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RemindAt  *time.Time `json:"remindAt,omitempty"`
+}
+
 // ProjectInfo aggregates all data we know about a project
 type ProjectInfo struct {
 	Path             string         `json:"path"`
 	ShortName        string         `json:"shortName"`
+	Aliases          []string       `json:"aliases,omitempty"`
+	Host             string         `json:"host,omitempty"` // set for projects seen only on another machine
+	Profiles         []string       `json:"profiles,omitempty"` // Claude profiles the project was used in, when several are read
+	Tags             []string       `json:"tags,omitempty"`
+	Group            string         `json:"group,omitempty"`
+	Notes            []Note         `json:"notes,omitempty"` // newest first
+	ReminderDue      bool           `json:"reminderDue,omitempty"`
+	AckedBy          string         `json:"ackedBy,omitempty"` // the ack rule that matched, if any
+	IgnoredBy        string         `json:"ignoredBy,omitempty"`
+	WokenBy          string         `json:"wokenBy,omitempty"` // wake-up event that ended a snooze
 	PromptCount      int            `json:"promptCount"`
 	LastActivity     time.Time      `json:"lastActivity"`
 	FirstActivity    time.Time      `json:"firstActivity"`
 	LastPrompt       string         `json:"lastPrompt"`
 	Sessions         []SessionEntry `json:"sessions,omitempty"`
+	Activity         []int          `json:"-"` // prompts per slice of the time window, oldest first
 	LatestSummary    string         `json:"latestSummary,omitempty"`
 	LatestBranch     string         `json:"latestBranch,omitempty"`
 	// Populated by deep analysis
@@ -89,3 +107,9 @@ type ProjectInfo struct {
 	IsOpenWork       bool    `json:"isOpenWork"`
 	Score            float64 `json:"score"`
 }
+
+// Paths returns the project's canonical path followed by any aliased paths
+// that were merged into it.
+func (p ProjectInfo) Paths() []string {
+	return append([]string{p.Path}, p.Aliases...)
+}
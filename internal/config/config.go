@@ -1,12 +1,12 @@
 package config
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,11 +15,68 @@ type AckEntry struct {
 	Path      string     `json:"path"`
 	AckedAt   time.Time  `json:"ackedAt"`
 	ExpiresAt *time.Time `json:"expiresAt"`
+	// WakeOn lists events that end the acknowledgement early (see WakeEvents).
+	WakeOn []string `json:"wakeOn,omitempty"`
+	// Snapshot is the project state at ack time that WakeOn events compare against.
+	Snapshot *AckSnapshot `json:"snapshot,omitempty"`
+}
+
+// Tombstone records that the acknowledgement for Path was removed, so the
+// removal can win over older acks when merging state from other machines.
+type Tombstone struct {
+	Path      string    `json:"path"`
+	RemovedAt time.Time `json:"removedAt"`
+}
+
+// PathRewrite maps a path prefix recorded on one machine (e.g. "/Users/me")
+// to the prefix used for the same tree elsewhere (e.g. "/home/me").
+type PathRewrite struct {
+	From string `json:"from"`
+	To   string `json:"to"`
 }
 
 // Config is the top-level squirrel configuration.
 type Config struct {
-	Acknowledged []AckEntry `json:"acknowledged"`
+	// Version is the schema version, see CurrentVersion.
+	Version      int           `json:"version"`
+	Acknowledged []AckEntry    `json:"acknowledged"`
+	AckRules     []AckRule     `json:"ackRules,omitempty"`
+	Unacked      []Tombstone   `json:"unacked,omitempty"`
+	PathRewrites []PathRewrite `json:"pathRewrites,omitempty"`
+	// MergeBy lists the git identities ("remote", "root-commit") used to merge
+	// checkouts of the same repository living at different paths, tried in order.
+	MergeBy []string `json:"mergeBy,omitempty"`
+	// SyncDir is the shared directory used by "squirrel sync".
+	SyncDir string `json:"syncDir,omitempty"`
+	// Host overrides the machine name used in the sync directory.
+	Host string `json:"host,omitempty"`
+	// Tags maps project paths to user-defined tags.
+	Tags   map[string][]string `json:"tags,omitempty"`
+	Groups []Group             `json:"groups,omitempty"`
+	Notes  []NoteEntry         `json:"notes,omitempty"`
+	// Ignore lists exact paths and path globs of projects that are excluded
+	// from all reports.
+	Ignore []string `json:"ignore,omitempty"`
+	// Defaults holds default values for CLI flags, keyed by flag name.
+	Defaults FlagValues `json:"defaults,omitempty"`
+	// Commands holds per-command flag defaults that win over Defaults.
+	Commands map[string]FlagValues `json:"commands,omitempty"`
+
+	// now is the clock for timestamps and expiry checks, see SetClock.
+	now func() time.Time
+}
+
+// SetClock makes the config use now instead of time.Now for timestamps and
+// expiry checks, e.g. to evaluate acknowledgements as of a past date.
+func (c *Config) SetClock(now func() time.Time) {
+	c.now = now
+}
+
+func (c *Config) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
 }
 
 var durationRe = regexp.MustCompile(`^(\d+)([dwm])$`)
@@ -49,74 +106,149 @@ func ParseDuration(s string) (time.Duration, error) {
 	return time.Duration(days) * 24 * time.Hour, nil
 }
 
-// DefaultPath returns the default config file path.
+// DefaultPath returns the default config file path, honouring XDG_CONFIG_HOME.
 func DefaultPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "squirrel", "config.json")
+	}
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, ".config", "squirrel", "config.json")
 }
 
-// Load reads the config from path. Returns empty config if file doesn't exist.
-func Load(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return &Config{}, nil
+// RewritePath applies the longest matching path rewrite rule to path.
+// Paths not covered by any rule are returned unchanged.
+func (c *Config) RewritePath(path string) string {
+	bestFrom, bestTo := "", ""
+	for _, r := range c.PathRewrites {
+		from := strings.TrimSuffix(expandHome(r.From), "/")
+		if from == "" || len(from) <= len(bestFrom) {
+			continue
+		}
+		if path == from || strings.HasPrefix(path, from+"/") {
+			bestFrom = from
+			bestTo = strings.TrimSuffix(expandHome(r.To), "/")
 		}
-		return nil, fmt.Errorf("reading config: %w", err)
 	}
-
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("parsing config: %w", err)
+	if bestFrom == "" {
+		return path
 	}
-	return &cfg, nil
+	return bestTo + strings.TrimPrefix(path, bestFrom)
 }
 
-// Save writes the config to path, creating parent directories as needed.
-func Save(cfg *Config, path string) error {
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-		return fmt.Errorf("creating config directory: %w", err)
+// expandHome replaces a leading "~" with the current user's home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
 	}
-
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	home, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("marshaling config: %w", err)
+		return path
 	}
-
-	return os.WriteFile(path, data, 0644)
+	return home + strings.TrimPrefix(path, "~")
 }
 
 // IsAcknowledged checks if a project path is acknowledged and not expired.
+// Wake-up conditions are not considered; see MatchAck.
 func (c *Config) IsAcknowledged(path string) bool {
+	_, ok := c.activeAck(path, c.clock())
+	return ok
+}
+
+// activeAck returns the acknowledgement for path if it has not expired by
+// now.
+func (c *Config) activeAck(path string, now time.Time) (AckEntry, bool) {
 	for _, e := range c.Acknowledged {
 		if e.Path == path {
-			if e.ExpiresAt != nil && e.ExpiresAt.Before(time.Now()) {
-				return false
+			if e.ExpiresAt != nil && e.ExpiresAt.Before(now) {
+				return AckEntry{}, false
 			}
-			return true
+			return e, true
 		}
 	}
-	return false
+	return AckEntry{}, false
 }
 
 // Ack adds or updates an acknowledgement for a project path.
 func (c *Config) Ack(path string, expiresAt *time.Time) {
-	for i, e := range c.Acknowledged {
-		if e.Path == path {
-			c.Acknowledged[i].AckedAt = time.Now()
-			c.Acknowledged[i].ExpiresAt = expiresAt
-			return
-		}
-	}
-	c.Acknowledged = append(c.Acknowledged, AckEntry{
+	c.Snooze(path, expiresAt, nil, nil)
+}
+
+// Snooze acknowledges a project path until it expires or one of the wakeOn
+// events happens relative to the snapshot taken at ack time.
+func (c *Config) Snooze(path string, expiresAt *time.Time, wakeOn []string, snapshot *AckSnapshot) {
+	c.clearTombstone(path)
+	c.setAck(AckEntry{
 		Path:      path,
-		AckedAt:   time.Now(),
+		AckedAt:   c.clock(),
 		ExpiresAt: expiresAt,
+		WakeOn:    wakeOn,
+		Snapshot:  snapshot,
 	})
 }
 
 // Unack removes the acknowledgement for a project path. Returns true if found.
 func (c *Config) Unack(path string) bool {
+	for i, e := range c.Acknowledged {
+		if e.Path == path {
+			c.Acknowledged = append(c.Acknowledged[:i], c.Acknowledged[i+1:]...)
+			c.setTombstone(Tombstone{Path: path, RemovedAt: c.clock()})
+			return true
+		}
+	}
+	return false
+}
+
+// MergeAcks merges acknowledgements from another machine into the config using
+// last-writer-wins per path: an incoming ack or removal replaces the local state
+// only if it happened later than the local ack (AckedAt) or removal (RemovedAt).
+// Returns the number of paths whose state changed.
+func (c *Config) MergeAcks(acks []AckEntry, removed []Tombstone) int {
+	changed := 0
+	for _, a := range acks {
+		if a.AckedAt.After(c.lastChange(a.Path)) {
+			c.clearTombstone(a.Path)
+			c.setAck(a)
+			changed++
+		}
+	}
+	for _, t := range removed {
+		if t.RemovedAt.After(c.lastChange(t.Path)) {
+			if c.removeAck(t.Path) {
+				changed++
+			}
+			c.setTombstone(t)
+		}
+	}
+	return changed
+}
+
+// lastChange returns when the local ack state for path last changed.
+func (c *Config) lastChange(path string) time.Time {
+	var last time.Time
+	for _, e := range c.Acknowledged {
+		if e.Path == path && e.AckedAt.After(last) {
+			last = e.AckedAt
+		}
+	}
+	for _, t := range c.Unacked {
+		if t.Path == path && t.RemovedAt.After(last) {
+			last = t.RemovedAt
+		}
+	}
+	return last
+}
+
+func (c *Config) setAck(entry AckEntry) {
+	for i, e := range c.Acknowledged {
+		if e.Path == entry.Path {
+			c.Acknowledged[i] = entry
+			return
+		}
+	}
+	c.Acknowledged = append(c.Acknowledged, entry)
+}
+
+func (c *Config) removeAck(path string) bool {
 	for i, e := range c.Acknowledged {
 		if e.Path == path {
 			c.Acknowledged = append(c.Acknowledged[:i], c.Acknowledged[i+1:]...)
@@ -125,3 +257,22 @@ func (c *Config) Unack(path string) bool {
 	}
 	return false
 }
+
+func (c *Config) setTombstone(t Tombstone) {
+	for i, e := range c.Unacked {
+		if e.Path == t.Path {
+			c.Unacked[i] = t
+			return
+		}
+	}
+	c.Unacked = append(c.Unacked, t)
+}
+
+func (c *Config) clearTombstone(path string) {
+	for i, t := range c.Unacked {
+		if t.Path == path {
+			c.Unacked = append(c.Unacked[:i], c.Unacked[i+1:]...)
+			return
+		}
+	}
+}
@@ -98,6 +98,26 @@ func TestIsAcknowledged(t *testing.T) {
 	}
 }
 
+func TestSetClock(t *testing.T) {
+	now := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	cfg := &Config{}
+	cfg.SetClock(func() time.Time { return now })
+
+	expires := now.Add(48 * time.Hour)
+	cfg.Ack("/projects/foo", &expires)
+	if !cfg.Acknowledged[0].AckedAt.Equal(now) {
+		t.Errorf("AckedAt = %v, want the injected clock %v", cfg.Acknowledged[0].AckedAt, now)
+	}
+	if !cfg.IsAcknowledged("/projects/foo") {
+		t.Error("ack should be active before it expires")
+	}
+
+	now = now.Add(72 * time.Hour)
+	if cfg.IsAcknowledged("/projects/foo") {
+		t.Error("ack should have expired by the injected clock")
+	}
+}
+
 func TestAckIdempotent(t *testing.T) {
 	cfg := &Config{}
 	cfg.Ack("/projects/foo", nil)
@@ -123,3 +143,90 @@ func TestUnack(t *testing.T) {
 		t.Error("Unack should return false for nonexistent entry")
 	}
 }
+
+func TestRewritePath(t *testing.T) {
+	cfg := &Config{PathRewrites: []PathRewrite{
+		{From: "/Users/me", To: "/home/me"},
+		{From: "/Users/me/src/client", To: "/home/me/work/client"},
+		{From: "/mnt/colleague/", To: "/home/me/"},
+	}}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"/Users/me/src/x", "/home/me/src/x"},
+		{"/Users/me", "/home/me"},
+		{"/Users/me/src/client/app", "/home/me/work/client/app"},
+		{"/mnt/colleague/src/x", "/home/me/src/x"},
+		{"/Users/meyer/src/x", "/Users/meyer/src/x"},
+		{"/opt/other", "/opt/other"},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.RewritePath(tt.input); got != tt.expected {
+			t.Errorf("RewritePath(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestMergeAcksLastWriterWins(t *testing.T) {
+	now := time.Now()
+
+	local := &Config{
+		Acknowledged: []AckEntry{
+			{Path: "/projects/old-local", AckedAt: now.Add(-2 * time.Hour)},
+			{Path: "/projects/new-local", AckedAt: now},
+		},
+		Unacked: []Tombstone{
+			{Path: "/projects/removed-local", RemovedAt: now.Add(-time.Hour)},
+		},
+	}
+
+	changed := local.MergeAcks(
+		[]AckEntry{
+			{Path: "/projects/new-local", AckedAt: now.Add(-time.Hour)},
+			{Path: "/projects/removed-local", AckedAt: now.Add(-30 * time.Minute)},
+			{Path: "/projects/remote-only", AckedAt: now},
+		},
+		[]Tombstone{
+			{Path: "/projects/old-local", RemovedAt: now.Add(-time.Hour)},
+		},
+	)
+
+	if changed != 3 {
+		t.Errorf("expected 3 changes, got %d", changed)
+	}
+	if local.IsAcknowledged("/projects/old-local") {
+		t.Error("newer remote removal should win over older local ack")
+	}
+	if !local.IsAcknowledged("/projects/new-local") {
+		t.Error("newer local ack should win over older remote ack")
+	}
+	if !local.IsAcknowledged("/projects/removed-local") {
+		t.Error("newer remote ack should win over older local removal")
+	}
+	if !local.IsAcknowledged("/projects/remote-only") {
+		t.Error("remote-only ack should be imported")
+	}
+	for _, ts := range local.Unacked {
+		if ts.Path == "/projects/removed-local" {
+			t.Error("tombstone should be cleared when a newer ack wins")
+		}
+	}
+}
+
+func TestUnackRecordsTombstone(t *testing.T) {
+	cfg := &Config{}
+	cfg.Ack("/projects/foo", nil)
+	cfg.Unack("/projects/foo")
+
+	if len(cfg.Unacked) != 1 || cfg.Unacked[0].Path != "/projects/foo" {
+		t.Fatalf("expected tombstone for /projects/foo, got %v", cfg.Unacked)
+	}
+
+	cfg.Ack("/projects/foo", nil)
+	if len(cfg.Unacked) != 0 {
+		t.Errorf("re-ack should clear tombstone, got %v", cfg.Unacked)
+	}
+}
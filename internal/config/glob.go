@@ -0,0 +1,94 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MatchGlob reports whether path matches the glob pattern. A leading "~" is
+// expanded to the home directory, "*" and "?" match within one path segment,
+// "**" matches across segments, and a trailing "/**" also matches the
+// directory itself. "[abc]", "[a-z]" and their negations "[!abc]" match one
+// character of a segment; a "[" without a closing "]" is literal.
+func MatchGlob(pattern, path string) bool {
+	re, err := globRegexp(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(path)
+}
+
+// IsGlob reports whether s contains glob metacharacters, including the "["
+// of a character class.
+func IsGlob(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	p := expandHome(pattern)
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; {
+		case strings.HasPrefix(p[i:], "/**") && i+3 == len(p):
+			b.WriteString("(?:/.*)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			class, n := globClass(p[i:])
+			if n == 0 {
+				b.WriteString(regexp.QuoteMeta("["))
+				break
+			}
+			b.WriteString(class)
+			i += n - 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// globClass translates the character class at the start of p to a regular
+// expression and returns it with the class's length in p, or 0 if the class
+// is not terminated.
+func globClass(p string) (string, int) {
+	i := 1
+	negate := i < len(p) && (p[i] == '!' || p[i] == '^')
+	if negate {
+		i++
+	}
+	var b strings.Builder
+	b.WriteString("[")
+	if negate {
+		b.WriteString("^/")
+	}
+	for start := i; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case c == ']' && i > start:
+			b.WriteString("]")
+			return b.String(), i + 1
+		case c == '-' && i > start && i+1 < len(p) && p[i+1] != ']':
+			b.WriteString("-")
+		case c == '/':
+			return "", 0
+		case c == '-': // a literal dash at either end of the class
+			b.WriteString(`\-`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return "", 0
+}
@@ -0,0 +1,39 @@
+package config
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/tmp/*", "/tmp/scratch", true},
+		{"/tmp/*", "/tmp/scratch/deep", false},
+		{"/tmp/**", "/tmp/scratch/deep", true},
+		{"/tmp/**", "/tmp", true},
+		{"/tmp/**", "/tmpfoo", false},
+		{"/src/**/typo3-*", "/src/clients/acme/typo3-site", true},
+		{"/src/**/typo3-*", "/src/typo3-core", true},
+		{"/src/app?", "/src/app1", true},
+		{"/src/app?", "/src/app12", false},
+		{"/src/a.b", "/src/aXb", false},
+		{"/src/app[12]", "/src/app1", true},
+		{"/src/app[12]", "/src/app[12]", false},
+		{"/src/app[12]", "/src/app3", false},
+		{"/src/app[0-9]", "/src/app7", true},
+		{"/src/app[!0-9]", "/src/appx", true},
+		{"/src/app[!0-9]", "/src/app7", false},
+		{"/src[!x]app", "/src/app", false},
+		{"/src/app[-_]x", "/src/app-x", true},
+		{"/src/app[]]", "/src/app]", true},
+		{"/src/app[x", "/src/app[x", true},
+		{"/src/[ab/c]", "/src/[ab/c]", true},
+	}
+
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// AddIgnore adds a path or glob to the ignore list. Returns false if it was
+// already present.
+func (c *Config) AddIgnore(entry string) bool {
+	entry = normalizeIgnore(entry)
+	if slices.Contains(c.Ignore, entry) {
+		return false
+	}
+	c.Ignore = append(c.Ignore, entry)
+	return true
+}
+
+// RemoveIgnore removes a path or glob from the ignore list. Returns true if found.
+func (c *Config) RemoveIgnore(entry string) bool {
+	entry = normalizeIgnore(entry)
+	i := slices.Index(c.Ignore, entry)
+	if i < 0 {
+		return false
+	}
+	c.Ignore = slices.Delete(c.Ignore, i, i+1)
+	return true
+}
+
+// IgnoredBy returns the ignore entry matching any of the given paths
+// (a project's canonical path and its aliases).
+func (c *Config) IgnoredBy(paths ...string) (string, bool) {
+	for _, entry := range c.Ignore {
+		for _, p := range paths {
+			if matchIgnore(entry, p) {
+				return entry, true
+			}
+		}
+	}
+	return "", false
+}
+
+func matchIgnore(entry, path string) bool {
+	if IsGlob(entry) {
+		return MatchGlob(entry, path)
+	}
+	return path == expandHome(entry)
+}
+
+func normalizeIgnore(entry string) string {
+	entry = strings.TrimSpace(entry)
+	if IsGlob(entry) || entry == "/" {
+		return entry
+	}
+	return filepath.Clean(entry)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIgnoredBy(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	cfg := &Config{}
+	cfg.AddIgnore("~")
+	cfg.AddIgnore("/tmp/**")
+	cfg.AddIgnore("~/src/vendor-*")
+
+	tests := []struct {
+		path      string
+		wantEntry string
+		want      bool
+	}{
+		{home, "~", true},
+		{filepath.Join(home, "notes"), "", false},
+		{"/tmp", "/tmp/**", true},
+		{"/tmp/experiment", "/tmp/**", true},
+		{"/tmpfoo", "", false},
+		{filepath.Join(home, "src", "vendor-typo3"), "~/src/vendor-*", true},
+		{filepath.Join(home, "src", "app"), "", false},
+	}
+
+	for _, tt := range tests {
+		entry, ok := cfg.IgnoredBy(tt.path)
+		if ok != tt.want || entry != tt.wantEntry {
+			t.Errorf("IgnoredBy(%q) = %q, %v; want %q, %v", tt.path, entry, ok, tt.wantEntry, tt.want)
+		}
+	}
+}
+
+func TestAddRemoveIgnore(t *testing.T) {
+	cfg := &Config{}
+	if !cfg.AddIgnore("/tmp") {
+		t.Error("first AddIgnore should return true")
+	}
+	if cfg.AddIgnore("/tmp/") {
+		t.Error("AddIgnore of equivalent path should return false")
+	}
+	if !cfg.RemoveIgnore("/tmp/") {
+		t.Error("RemoveIgnore should find the normalized entry")
+	}
+	if len(cfg.Ignore) != 0 {
+		t.Errorf("expected empty ignore list, got %v", cfg.Ignore)
+	}
+}
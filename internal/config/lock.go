@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+const (
+	lockTimeout = 10 * time.Second
+	lockRetry   = 20 * time.Millisecond
+	lockStale   = 30 * time.Second
+)
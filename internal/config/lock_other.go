@@ -0,0 +1,35 @@
+//go:build !unix
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// lock creates path+".lock" exclusively, waiting up to lockTimeout for other
+// squirrel processes to remove it. Lock files older than lockStale are
+// considered left over from a crash and taken over.
+func lock(path string) (unlock func(), err error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("creating config lock: %w", err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > lockStale {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("config %s is locked by another squirrel process", path)
+		}
+		time.Sleep(lockRetry)
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"sort"
+	"time"
+)
+
+// NoteEntry is a breadcrumb left on a project. All notes are kept as history;
+// the most recent one is the current "next step".
+type NoteEntry struct {
+	Path      string     `json:"path"`
+	Text      string     `json:"text"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RemindAt  *time.Time `json:"remindAt,omitempty"`
+}
+
+// AddNote records a note for a project path, optionally with a reminder date.
+func (c *Config) AddNote(path, text string, remindAt *time.Time) NoteEntry {
+	n := NoteEntry{
+		Path:      path,
+		Text:      text,
+		CreatedAt: c.clock(),
+		RemindAt:  remindAt,
+	}
+	c.Notes = append(c.Notes, n)
+	return n
+}
+
+// NotesFor returns the notes of the given paths (a project's canonical path
+// and its aliases), newest first.
+func (c *Config) NotesFor(paths ...string) []NoteEntry {
+	var notes []NoteEntry
+	for _, n := range c.Notes {
+		for _, p := range paths {
+			if n.Path == p {
+				notes = append(notes, n)
+				break
+			}
+		}
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.After(notes[j].CreatedAt)
+	})
+	return notes
+}
+
+// DueReminder returns the note whose reminder has come due for the given
+// paths. A reminder counts as handled once a newer note has been added after
+// it fired, so leaving a fresh breadcrumb dismisses it.
+func (c *Config) DueReminder(now time.Time, paths ...string) (NoteEntry, bool) {
+	notes := c.NotesFor(paths...)
+	for i, n := range notes {
+		if n.RemindAt == nil || n.RemindAt.After(now) {
+			continue
+		}
+		// notes[:i] are newer; any written after the reminder fired dismisses it
+		for _, newer := range notes[:i] {
+			if newer.CreatedAt.After(*n.RemindAt) {
+				return NoteEntry{}, false
+			}
+		}
+		return n, true
+	}
+	return NoteEntry{}, false
+}
+
+// DueReminderPaths returns the canonical path, the first of its paths, of
+// each project with a due reminder. Like DueReminder, it looks at all of a
+// project's paths, so a reminder on one alias is dismissed by a newer note on
+// another.
+func (c *Config) DueReminderPaths(now time.Time, projects ...[]string) []string {
+	var paths []string
+	for _, p := range projects {
+		if len(p) == 0 {
+			continue
+		}
+		if _, ok := c.DueReminder(now, p...); ok {
+			paths = append(paths, p[0])
+		}
+	}
+	return paths
+}
+
+// HasFiredReminders reports whether any note's reminder date has passed,
+// whether or not a newer note dismissed it since.
+func (c *Config) HasFiredReminders(now time.Time) bool {
+	for _, n := range c.Notes {
+		if n.RemindAt != nil && !n.RemindAt.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeNotes adds notes from another machine that are not yet known locally.
+// Notes are append-only, so merging is a union keyed by path, time and text.
+// Returns the number of notes added.
+func (c *Config) MergeNotes(notes []NoteEntry) int {
+	added := 0
+	for _, n := range notes {
+		known := false
+		for _, e := range c.Notes {
+			if e.Path == n.Path && e.CreatedAt.Equal(n.CreatedAt) && e.Text == n.Text {
+				known = true
+				break
+			}
+		}
+		if !known {
+			c.Notes = append(c.Notes, n)
+			added++
+		}
+	}
+	return added
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotesForNewestFirst(t *testing.T) {
+	now := time.Now()
+	cfg := &Config{Notes: []NoteEntry{
+		{Path: "/p/a", Text: "first", CreatedAt: now.Add(-2 * time.Hour)},
+		{Path: "/p/b", Text: "other", CreatedAt: now.Add(-time.Hour)},
+		{Path: "/p/alias", Text: "second", CreatedAt: now},
+	}}
+
+	notes := cfg.NotesFor("/p/a", "/p/alias")
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+	if notes[0].Text != "second" || notes[1].Text != "first" {
+		t.Errorf("expected newest first, got %q, %q", notes[0].Text, notes[1].Text)
+	}
+}
+
+func TestDueReminder(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	cfg := &Config{}
+	cfg.Notes = []NoteEntry{
+		{Path: "/p/due", Text: "resume", CreatedAt: now.Add(-72 * time.Hour), RemindAt: &past},
+		{Path: "/p/later", Text: "not yet", CreatedAt: now.Add(-time.Hour), RemindAt: &future},
+		{Path: "/p/handled", Text: "old", CreatedAt: now.Add(-72 * time.Hour), RemindAt: &past},
+		{Path: "/p/handled", Text: "picked it up", CreatedAt: now.Add(-time.Minute)},
+	}
+
+	if n, ok := cfg.DueReminder(now, "/p/due"); !ok || n.Text != "resume" {
+		t.Errorf("expected due reminder 'resume', got %v %v", n, ok)
+	}
+	if _, ok := cfg.DueReminder(now, "/p/later"); ok {
+		t.Error("future reminder should not be due")
+	}
+	if _, ok := cfg.DueReminder(now, "/p/handled"); ok {
+		t.Error("reminder followed by a newer note should be dismissed")
+	}
+
+	paths := cfg.DueReminderPaths(now, []string{"/p/due"}, []string{"/p/later"}, []string{"/p/handled"})
+	if len(paths) != 1 || paths[0] != "/p/due" {
+		t.Errorf("expected [/p/due], got %v", paths)
+	}
+	if !cfg.HasFiredReminders(now) || cfg.HasFiredReminders(past.Add(-time.Minute)) {
+		t.Error("HasFiredReminders should report fired reminders only")
+	}
+}
+
+func TestDueReminderPathsAliases(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+
+	cfg := &Config{}
+	cfg.Notes = []NoteEntry{
+		{Path: "/home/me/app", Text: "resume", CreatedAt: now.Add(-72 * time.Hour), RemindAt: &past},
+		{Path: "/home/me/lib", Text: "old", CreatedAt: now.Add(-72 * time.Hour), RemindAt: &past},
+		{Path: "/Users/me/lib", Text: "picked it up", CreatedAt: now.Add(-time.Minute)},
+	}
+
+	// A reminder on an alias makes the project due under its canonical path,
+	// and a newer note on another alias dismisses it
+	paths := cfg.DueReminderPaths(now,
+		[]string{"/Users/me/app", "/home/me/app"},
+		[]string{"/Users/me/lib", "/home/me/lib"},
+	)
+	if len(paths) != 1 || paths[0] != "/Users/me/app" {
+		t.Errorf("expected [/Users/me/app], got %v", paths)
+	}
+}
+
+func TestMergeNotes(t *testing.T) {
+	now := time.Now()
+	cfg := &Config{}
+	n := cfg.AddNote("/p/a", "local", nil)
+
+	added := cfg.MergeNotes([]NoteEntry{n, {Path: "/p/a", Text: "remote", CreatedAt: now}})
+	if added != 1 {
+		t.Errorf("expected 1 note added, got %d", added)
+	}
+	if len(cfg.Notes) != 2 {
+		t.Errorf("expected 2 notes, got %d", len(cfg.Notes))
+	}
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// AckRule acknowledges every project whose path matches Pattern and which
+// satisfies all of the rule's conditions.
+type AckRule struct {
+	// Pattern is a glob (see MatchGlob), or a regular expression if Regex is set.
+	Pattern string `json:"pattern"`
+	Regex   bool   `json:"regex,omitempty"`
+	// NotGitRepo restricts the rule to directories that are not git repositories.
+	NotGitRepo bool `json:"notGitRepo,omitempty"`
+	// MaxPrompts restricts the rule to projects with fewer prompts than this.
+	MaxPrompts int `json:"maxPrompts,omitempty"`
+	// HomeRoot restricts the rule to the home directory and its direct children.
+	HomeRoot  bool       `json:"homeRoot,omitempty"`
+	AckedAt   time.Time  `json:"ackedAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// AckSubject describes a project for evaluating acknowledgements.
+type AckSubject struct {
+	// Paths holds the project's canonical path followed by its aliases.
+	Paths       []string
+	PromptCount int
+	// IsGitRepo is only called for rules with the NotGitRepo condition.
+	IsGitRepo func() bool
+	// State is only called for acks with wake-up conditions.
+	State func() AckSnapshot
+	// Now is the time expiry is checked against; zero means the config's
+	// clock.
+	Now time.Time
+}
+
+// AckMatch is the outcome of MatchAck.
+type AckMatch struct {
+	Acknowledged bool
+	// Rule describes the matching ack rule; empty for explicit acks.
+	Rule string
+	// WokenBy names the wake-up event that ended a snooze, if any.
+	WokenBy string
+}
+
+// String describes the rule for display, e.g. "/tmp/* (not a git repo, < 3 prompts)".
+func (r AckRule) String() string {
+	s := r.Pattern
+	if r.Regex {
+		s = "/" + r.Pattern + "/"
+	}
+	var conds []string
+	if r.NotGitRepo {
+		conds = append(conds, "not a git repo")
+	}
+	if r.MaxPrompts > 0 {
+		conds = append(conds, fmt.Sprintf("< %d prompts", r.MaxPrompts))
+	}
+	if r.HomeRoot {
+		conds = append(conds, "home dir root")
+	}
+	if len(conds) > 0 {
+		s += " (" + strings.Join(conds, ", ") + ")"
+	}
+	return s
+}
+
+// Validate checks that the rule's pattern can be compiled.
+func (r AckRule) Validate() error {
+	if r.Pattern == "" {
+		return fmt.Errorf("ack rule needs a pattern")
+	}
+	if r.Regex {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", r.Pattern, err)
+		}
+		return nil
+	}
+	if _, err := globRegexp(r.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", r.Pattern, err)
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to the subject, ignoring expiry.
+func (r AckRule) Matches(s AckSubject) bool {
+	if r.MaxPrompts > 0 && s.PromptCount >= r.MaxPrompts {
+		return false
+	}
+
+	matched := false
+	for _, p := range s.Paths {
+		if r.matchesPath(p) && (!r.HomeRoot || isHomeRoot(p)) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+
+	if r.NotGitRepo && s.IsGitRepo != nil && s.IsGitRepo() {
+		return false
+	}
+	return true
+}
+
+func (r AckRule) matchesPath(path string) bool {
+	if r.Regex {
+		re, err := regexp.Compile(r.Pattern)
+		return err == nil && re.MatchString(path)
+	}
+	return MatchGlob(r.Pattern, path)
+}
+
+func isHomeRoot(path string) bool {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+	return path == home || filepath.Dir(path) == home
+}
+
+// AddAckRule adds a rule, replacing an existing rule with the same pattern.
+func (c *Config) AddAckRule(rule AckRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	rule.AckedAt = c.clock()
+	for i, r := range c.AckRules {
+		if r.Pattern == rule.Pattern && r.Regex == rule.Regex {
+			c.AckRules[i] = rule
+			return nil
+		}
+	}
+	c.AckRules = append(c.AckRules, rule)
+	return nil
+}
+
+// RemoveAckRule removes the rules with the given pattern. Returns true if found.
+func (c *Config) RemoveAckRule(pattern string) bool {
+	kept := c.AckRules[:0]
+	for _, r := range c.AckRules {
+		if r.Pattern != pattern {
+			kept = append(kept, r)
+		}
+	}
+	removed := len(kept) != len(c.AckRules)
+	c.AckRules = kept
+	return removed
+}
+
+// MatchAck reports whether the subject is acknowledged, either by an explicit
+// ack of one of its paths or by an unexpired rule. An explicit ack whose
+// wake-up condition has fired no longer counts and reports WokenBy instead.
+func (c *Config) MatchAck(s AckSubject) AckMatch {
+	now := s.Now
+	if now.IsZero() {
+		now = c.clock()
+	}
+	for _, p := range s.Paths {
+		e, ok := c.activeAck(p, now)
+		if !ok {
+			continue
+		}
+		if len(e.WakeOn) > 0 && e.Snapshot != nil && s.State != nil {
+			if event := e.WokenBy(s.State()); event != "" {
+				return AckMatch{WokenBy: event}
+			}
+		}
+		return AckMatch{Acknowledged: true}
+	}
+	for _, r := range c.AckRules {
+		if r.ExpiresAt != nil && r.ExpiresAt.Before(now) {
+			continue
+		}
+		if r.Matches(s) {
+			return AckMatch{Acknowledged: true, Rule: r.String()}
+		}
+	}
+	return AckMatch{}
+}
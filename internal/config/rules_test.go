@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAckRuleMatches(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	isRepo := func() bool { return true }
+	notRepo := func() bool { return false }
+
+	tests := []struct {
+		name string
+		rule AckRule
+		subj AckSubject
+		want bool
+	}{
+		{"glob", AckRule{Pattern: "/tmp/*"}, AckSubject{Paths: []string{"/tmp/x"}}, true},
+		{"glob miss", AckRule{Pattern: "/tmp/*"}, AckSubject{Paths: []string{"/src/x"}}, false},
+		{"glob alias", AckRule{Pattern: "/tmp/*"}, AckSubject{Paths: []string{"/src/x", "/tmp/x"}}, true},
+		{"regex", AckRule{Pattern: `typo3`, Regex: true}, AckSubject{Paths: []string{"/src/acme-typo3-site"}}, true},
+		{"not git: repo", AckRule{Pattern: "/**", NotGitRepo: true}, AckSubject{Paths: []string{"/x"}, IsGitRepo: isRepo}, false},
+		{"not git: plain dir", AckRule{Pattern: "/**", NotGitRepo: true}, AckSubject{Paths: []string{"/x"}, IsGitRepo: notRepo}, true},
+		{"few prompts", AckRule{Pattern: "/**", MaxPrompts: 3}, AckSubject{Paths: []string{"/x"}, PromptCount: 2}, true},
+		{"many prompts", AckRule{Pattern: "/**", MaxPrompts: 3}, AckSubject{Paths: []string{"/x"}, PromptCount: 3}, false},
+		{"home root", AckRule{Pattern: "/**", HomeRoot: true}, AckSubject{Paths: []string{filepath.Join(home, "scratch")}}, true},
+		{"home nested", AckRule{Pattern: "/**", HomeRoot: true}, AckSubject{Paths: []string{filepath.Join(home, "src", "app")}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.subj); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchAckReason(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	cfg := &Config{}
+	cfg.Ack("/projects/explicit", nil)
+	if err := cfg.AddAckRule(AckRule{Pattern: "/tmp/*", MaxPrompts: 3}); err != nil {
+		t.Fatal(err)
+	}
+	cfg.AckRules = append(cfg.AckRules, AckRule{Pattern: "/old/*", ExpiresAt: &past})
+
+	if m := cfg.MatchAck(AckSubject{Paths: []string{"/projects/explicit"}}); !m.Acknowledged || m.Rule != "" {
+		t.Errorf("explicit ack: got %+v", m)
+	}
+	if m := cfg.MatchAck(AckSubject{Paths: []string{"/tmp/x"}, PromptCount: 1}); !m.Acknowledged || m.Rule != "/tmp/* (< 3 prompts)" {
+		t.Errorf("rule ack: got %+v", m)
+	}
+	if m := cfg.MatchAck(AckSubject{Paths: []string{"/old/x"}}); m.Acknowledged {
+		t.Error("expired rule should not match")
+	}
+}
+
+func TestAddRemoveAckRule(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.AddAckRule(AckRule{Pattern: "(", Regex: true}); err == nil {
+		t.Error("invalid regex should be rejected")
+	}
+
+	cfg.AddAckRule(AckRule{Pattern: "/tmp/*"})
+	cfg.AddAckRule(AckRule{Pattern: "/tmp/*", MaxPrompts: 5})
+	if len(cfg.AckRules) != 1 || cfg.AckRules[0].MaxPrompts != 5 {
+		t.Errorf("re-adding a pattern should replace the rule, got %+v", cfg.AckRules)
+	}
+
+	if !cfg.RemoveAckRule("/tmp/*") || len(cfg.AckRules) != 0 {
+		t.Error("RemoveAckRule should remove the rule")
+	}
+	if cfg.RemoveAckRule("/tmp/*") {
+		t.Error("RemoveAckRule should return false for unknown pattern")
+	}
+}
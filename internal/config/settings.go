@@ -0,0 +1,154 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// FlagValues maps flag names to default values in their command-line form.
+// In the config file values may also be written as JSON numbers, booleans
+// or string arrays (for list flags such as "tag").
+type FlagValues map[string]string
+
+// UnmarshalJSON accepts scalar and string array values.
+func (f *FlagValues) UnmarshalJSON(data []byte) error {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	values := make(FlagValues, len(raw))
+	for name, v := range raw {
+		switch v := v.(type) {
+		case string:
+			values[name] = v
+		case float64, bool:
+			values[name] = fmt.Sprint(v)
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return fmt.Errorf("flag default %q: list items must be strings", name)
+				}
+				items[i] = s
+			}
+			values[name] = strings.Join(items, ",")
+		default:
+			return fmt.Errorf("flag default %q: unsupported value %v", name, v)
+		}
+	}
+	*f = values
+	return nil
+}
+
+// FlagDefault returns the configured default for flag when running command.
+// Per-command values win over the global defaults section.
+func (c *Config) FlagDefault(command, flag string) (string, bool) {
+	if v, ok := c.Commands[command][flag]; ok {
+		return v, true
+	}
+	v, ok := c.Defaults[flag]
+	return v, ok
+}
+
+// Setting is a key/value pair as used by "squirrel config".
+type Setting struct {
+	Key   string
+	Value string
+}
+
+// Key is a parsed settings key. Keys are "syncDir", "host",
+// "defaults.<flag>" or "commands.<command>.<flag>".
+type Key struct {
+	// Name is the top-level setting, or "" for flag defaults.
+	Name    string
+	Command string
+	Flag    string
+}
+
+// ParseKey splits a dotted settings key.
+func ParseKey(key string) (Key, error) {
+	parts := strings.Split(key, ".")
+	switch {
+	case len(parts) == 1 && (key == "syncDir" || key == "host"):
+		return Key{Name: key}, nil
+	case len(parts) == 2 && parts[0] == "defaults" && parts[1] != "":
+		return Key{Flag: parts[1]}, nil
+	case len(parts) == 3 && parts[0] == "commands" && parts[1] != "" && parts[2] != "":
+		return Key{Command: parts[1], Flag: parts[2]}, nil
+	}
+	return Key{}, fmt.Errorf("unknown setting %q (use syncDir, host, defaults.<flag> or commands.<command>.<flag>)", key)
+}
+
+// Get returns the value stored under key.
+func (c *Config) Get(k Key) (string, bool) {
+	switch {
+	case k.Name == "syncDir":
+		return c.SyncDir, c.SyncDir != ""
+	case k.Name == "host":
+		return c.Host, c.Host != ""
+	case k.Command != "":
+		v, ok := c.Commands[k.Command][k.Flag]
+		return v, ok
+	default:
+		v, ok := c.Defaults[k.Flag]
+		return v, ok
+	}
+}
+
+// Set stores value under key. An empty value removes the setting.
+func (c *Config) Set(k Key, value string) {
+	switch {
+	case k.Name == "syncDir":
+		c.SyncDir = value
+	case k.Name == "host":
+		c.Host = value
+	case k.Command != "":
+		if value == "" {
+			delete(c.Commands[k.Command], k.Flag)
+			if len(c.Commands[k.Command]) == 0 {
+				delete(c.Commands, k.Command)
+			}
+			return
+		}
+		if c.Commands == nil {
+			c.Commands = map[string]FlagValues{}
+		}
+		if c.Commands[k.Command] == nil {
+			c.Commands[k.Command] = FlagValues{}
+		}
+		c.Commands[k.Command][k.Flag] = value
+	default:
+		if value == "" {
+			delete(c.Defaults, k.Flag)
+			return
+		}
+		if c.Defaults == nil {
+			c.Defaults = FlagValues{}
+		}
+		c.Defaults[k.Flag] = value
+	}
+}
+
+// Settings lists all scalar settings and flag defaults, sorted by key.
+func (c *Config) Settings() []Setting {
+	var out []Setting
+	if c.SyncDir != "" {
+		out = append(out, Setting{"syncDir", c.SyncDir})
+	}
+	if c.Host != "" {
+		out = append(out, Setting{"host", c.Host})
+	}
+	for _, flag := range slices.Sorted(maps.Keys(c.Defaults)) {
+		out = append(out, Setting{"defaults." + flag, c.Defaults[flag]})
+	}
+	for _, command := range slices.Sorted(maps.Keys(c.Commands)) {
+		for _, flag := range slices.Sorted(maps.Keys(c.Commands[command])) {
+			out = append(out, Setting{"commands." + command + "." + flag, c.Commands[command][flag]})
+		}
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestFlagValuesAcceptsJSONScalars(t *testing.T) {
+	var cfg Config
+	data := `{"defaults": {"days": 30, "json": true, "depth": "quick", "tag": ["work", "oss"]}}`
+	if err := json.Unmarshal([]byte(data), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	want := FlagValues{"days": "30", "json": "true", "depth": "quick", "tag": "work,oss"}
+	for k, v := range want {
+		if cfg.Defaults[k] != v {
+			t.Errorf("Defaults[%q] = %q, want %q", k, cfg.Defaults[k], v)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"defaults": {"days": {"x": 1}}}`), &cfg); err == nil {
+		t.Error("expected error for object value")
+	}
+}
+
+func TestFlagDefaultPrecedence(t *testing.T) {
+	cfg := &Config{
+		Defaults: FlagValues{"days": "30", "depth": "deep"},
+		Commands: map[string]FlagValues{"stash": {"depth": "quick"}},
+	}
+
+	if v, _ := cfg.FlagDefault("stash", "depth"); v != "quick" {
+		t.Errorf("stash depth = %q, want quick", v)
+	}
+	if v, _ := cfg.FlagDefault("status", "depth"); v != "deep" {
+		t.Errorf("status depth = %q, want deep", v)
+	}
+	if v, _ := cfg.FlagDefault("stash", "days"); v != "30" {
+		t.Errorf("stash days = %q, want 30", v)
+	}
+	if _, ok := cfg.FlagDefault("stash", "json"); ok {
+		t.Error("json should not have a default")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		want    Key
+		wantErr bool
+	}{
+		{"syncDir", Key{Name: "syncDir"}, false},
+		{"defaults.days", Key{Flag: "days"}, false},
+		{"commands.stash.depth", Key{Command: "stash", Flag: "depth"}, false},
+		{"defaults", Key{}, true},
+		{"commands.stash", Key{}, true},
+		{"acknowledged", Key{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseKey(tt.key)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseKey(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseKey(%q) = %+v, want %+v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestSetGetSettings(t *testing.T) {
+	cfg := &Config{}
+	cfg.Set(Key{Flag: "days"}, "30")
+	cfg.Set(Key{Command: "stash", Flag: "depth"}, "quick")
+	cfg.Set(Key{Name: "host"}, "laptop")
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := Save(cfg, path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings := loaded.Settings()
+	want := []Setting{{"host", "laptop"}, {"defaults.days", "30"}, {"commands.stash.depth", "quick"}}
+	if len(settings) != len(want) {
+		t.Fatalf("Settings() = %v, want %v", settings, want)
+	}
+	for i := range want {
+		if settings[i] != want[i] {
+			t.Errorf("Settings()[%d] = %v, want %v", i, settings[i], want[i])
+		}
+	}
+
+	loaded.Set(Key{Command: "stash", Flag: "depth"}, "")
+	if _, ok := loaded.Get(Key{Command: "stash", Flag: "depth"}); ok {
+		t.Error("empty value should remove the setting")
+	}
+	if loaded.Commands != nil && len(loaded.Commands) != 0 {
+		t.Errorf("empty command section should be removed, got %v", loaded.Commands)
+	}
+}
+
+func TestDefaultPathXDG(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if got := DefaultPath(); got != filepath.Join("/xdg", "squirrel", "config.json") {
+		t.Errorf("DefaultPath() = %q", got)
+	}
+	t.Setenv("XDG_CONFIG_HOME", "relative")
+	if got := DefaultPath(); filepath.Base(filepath.Dir(filepath.Dir(got))) == "relative" {
+		t.Errorf("relative XDG_CONFIG_HOME should be ignored, got %q", got)
+	}
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CurrentVersion is the config schema version written by Save.
+const CurrentVersion = 1
+
+// ErrUnchanged can be returned from an Update callback to skip writing.
+var ErrUnchanged = errors.New("config unchanged")
+
+// migrations[n] upgrades a raw config from schema version n to n+1.
+var migrations = []func(raw map[string]json.RawMessage) error{
+	// 0 -> 1: introduces the version field; unversioned files are otherwise compatible.
+	func(raw map[string]json.RawMessage) error { return nil },
+}
+
+// CorruptError is returned by Load when the config file cannot be parsed.
+type CorruptError struct {
+	Path      string
+	Err       error
+	HasBackup bool
+}
+
+func (e *CorruptError) Error() string {
+	if e.HasBackup {
+		return fmt.Sprintf("config %s is corrupt (%v); run \"squirrel config restore\" to go back to the last good version, or move the file aside to start fresh", e.Path, e.Err)
+	}
+	return fmt.Sprintf("config %s is corrupt (%v); fix or move the file aside to start fresh", e.Path, e.Err)
+}
+
+func (e *CorruptError) Unwrap() error { return e.Err }
+
+// BackupPath returns where Save keeps the previous version of the config.
+func BackupPath(path string) string {
+	return path + ".bak"
+}
+
+// Load reads the config from path. Returns empty config if file doesn't exist.
+// Files with an older schema version are migrated in memory; files that
+// cannot be parsed yield a *CorruptError.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{Version: CurrentVersion}, nil
+		}
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	cfg, err := parse(data)
+	if err != nil {
+		var verr *versionError
+		if errors.As(err, &verr) {
+			return nil, err
+		}
+		_, statErr := os.Stat(BackupPath(path))
+		return nil, &CorruptError{Path: path, Err: err, HasBackup: statErr == nil}
+	}
+	return cfg, nil
+}
+
+type versionError struct {
+	version int
+}
+
+func (e *versionError) Error() string {
+	return fmt.Sprintf("config schema version %d is newer than supported version %d; please update squirrel", e.version, CurrentVersion)
+}
+
+func parse(data []byte) (*Config, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, errors.New("not a JSON object")
+	}
+
+	version := 0
+	if v, ok := raw["version"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return nil, fmt.Errorf("invalid version: %w", err)
+		}
+	}
+	if version > CurrentVersion {
+		return nil, &versionError{version: version}
+	}
+	for v := version; v < CurrentVersion; v++ {
+		if err := migrations[v](raw); err != nil {
+			return nil, fmt.Errorf("migrating from version %d: %w", v, err)
+		}
+	}
+	raw["version"] = json.RawMessage(fmt.Sprint(CurrentVersion))
+
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(migrated, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Save writes the config to path, creating parent directories as needed.
+// The previous version is kept as a backup (see BackupPath) and the new one
+// is written to a temporary file first, so a crash never leaves a truncated
+// config behind. Use Update for read-modify-write cycles.
+func Save(cfg *Config, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	cfg.Version = CurrentVersion
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling config: %w", err)
+	}
+
+	if err := backup(path); err != nil {
+		return fmt.Errorf("backing up config: %w", err)
+	}
+	if err := writeAtomic(path, data); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
+// Update loads the config at path, applies fn and saves the result while
+// holding an exclusive lock, so concurrent squirrel processes cannot lose
+// each other's changes. Nothing is written if fn returns an error; returning
+// ErrUnchanged skips the write without failing.
+func Update(path string, fn func(*Config) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	unlock, err := lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cfg, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(cfg); err != nil {
+		if errors.Is(err, ErrUnchanged) {
+			return nil
+		}
+		return err
+	}
+	return Save(cfg, path)
+}
+
+// Restore replaces the config at path with its backup. The replaced file is
+// kept next to it with a ".corrupt" suffix for inspection.
+func Restore(path string) error {
+	unlock, err := lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := os.ReadFile(BackupPath(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no backup found at %s", BackupPath(path))
+		}
+		return fmt.Errorf("reading backup: %w", err)
+	}
+	if _, err := parse(data); err != nil {
+		return fmt.Errorf("backup %s is not usable either: %w", BackupPath(path), err)
+	}
+
+	if current, err := os.ReadFile(path); err == nil {
+		if err := writeAtomic(path+".corrupt", current); err != nil {
+			return fmt.Errorf("keeping replaced config: %w", err)
+		}
+	}
+	return writeAtomic(path, data)
+}
+
+// backup copies the current config to BackupPath, unless it is missing or
+// unparsable, so a good backup is never replaced by a broken file.
+func backup(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if _, err := parse(data); err != nil {
+		return nil
+	}
+	return writeAtomic(BackupPath(path), data)
+}
+
+// writeAtomic writes data to a temporary file in the target directory and
+// renames it over path.
+func writeAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
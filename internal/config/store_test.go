@@ -0,0 +1,154 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSaveKeepsBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	first := &Config{Ignore: []string{"/tmp/a"}}
+	if err := Save(first, path); err != nil {
+		t.Fatal(err)
+	}
+	second := &Config{Ignore: []string{"/tmp/b"}}
+	if err := Save(second, path); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := Load(BackupPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backup.Ignore) != 1 || backup.Ignore[0] != "/tmp/a" {
+		t.Errorf("backup = %v, want previous version", backup.Ignore)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Errorf("temp file %s left behind", e.Name())
+		}
+	}
+}
+
+func TestUpdateConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := Update(path, func(cfg *Config) error {
+				cfg.AddIgnore(fmt.Sprintf("/tmp/p%d", i))
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Ignore) != 20 {
+		t.Errorf("got %d entries, want 20 (lost updates)", len(cfg.Ignore))
+	}
+}
+
+func TestUpdateUnchangedSkipsWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	err := Update(path, func(cfg *Config) error { return ErrUnchanged })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected no config to be written")
+	}
+
+	want := errors.New("boom")
+	if err := Update(path, func(cfg *Config) error { return want }); !errors.Is(err, want) {
+		t.Errorf("Update error = %v, want %v", err, want)
+	}
+}
+
+func TestLoadMigratesUnversioned(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{"acknowledged":[{"path":"/a","ackedAt":"2026-01-01T00:00:00Z"}]}`), 0644)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentVersion)
+	}
+	if len(cfg.Acknowledged) != 1 || cfg.Acknowledged[0].Path != "/a" {
+		t.Errorf("Acknowledged = %v", cfg.Acknowledged)
+	}
+}
+
+func TestLoadRejectsNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(fmt.Sprintf(`{"version":%d}`, CurrentVersion+1)), 0644)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("expected error for newer schema version")
+	}
+	var corrupt *CorruptError
+	if errors.As(err, &corrupt) {
+		t.Error("newer version should not be reported as corrupt")
+	}
+}
+
+func TestLoadCorruptAndRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := Save(&Config{Ignore: []string{"/tmp/a"}}, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(&Config{Ignore: []string{"/tmp/b"}}, path); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(path, []byte(`{"ignore": [`), 0644)
+
+	_, err := Load(path)
+	var corrupt *CorruptError
+	if !errors.As(err, &corrupt) {
+		t.Fatalf("Load error = %v, want *CorruptError", err)
+	}
+	if !corrupt.HasBackup || !strings.Contains(err.Error(), "squirrel config restore") {
+		t.Errorf("error should point to restore: %v", err)
+	}
+
+	// A save on top of a corrupt file must not replace the good backup.
+	if err := Save(&Config{}, path); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(path, []byte(``), 0644)
+
+	if err := Restore(path); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Ignore) != 1 || cfg.Ignore[0] != "/tmp/a" {
+		t.Errorf("restored = %v, want /tmp/a", cfg.Ignore)
+	}
+	if _, err := os.Stat(path + ".corrupt"); err != nil {
+		t.Error("replaced file should be kept as .corrupt")
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// Group is a named set of projects selected by path globs (see MatchGlob).
+type Group struct {
+	Name  string   `json:"name"`
+	Paths []string `json:"paths"`
+}
+
+// Tag adds tags to a project path. Returns the tags that were newly added.
+func (c *Config) Tag(path string, tags ...string) []string {
+	if c.Tags == nil {
+		c.Tags = make(map[string][]string)
+	}
+	var added []string
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t == "" || slices.Contains(c.Tags[path], t) {
+			continue
+		}
+		c.Tags[path] = append(c.Tags[path], t)
+		added = append(added, t)
+	}
+	return added
+}
+
+// Untag removes tags from a project path, or all of its tags if none are
+// given. Returns the number of tags removed.
+func (c *Config) Untag(path string, tags ...string) int {
+	existing := c.Tags[path]
+	if len(tags) == 0 {
+		delete(c.Tags, path)
+		return len(existing)
+	}
+
+	kept := existing[:0]
+	for _, t := range existing {
+		if !slices.Contains(tags, t) {
+			kept = append(kept, t)
+		}
+	}
+	removed := len(existing) - len(kept)
+	if len(kept) == 0 {
+		delete(c.Tags, path)
+	} else {
+		c.Tags[path] = kept
+	}
+	return removed
+}
+
+// TagsFor returns the combined tags of the given paths (a project's canonical
+// path and its aliases), without duplicates.
+func (c *Config) TagsFor(paths ...string) []string {
+	var tags []string
+	for _, p := range paths {
+		for _, t := range c.Tags[p] {
+			if !slices.Contains(tags, t) {
+				tags = append(tags, t)
+			}
+		}
+	}
+	return tags
+}
+
+// GroupFor returns the name of the first group with a glob matching any of
+// the given paths, or "" if the project belongs to no group.
+func (c *Config) GroupFor(paths ...string) string {
+	for _, g := range c.Groups {
+		for _, pattern := range g.Paths {
+			for _, p := range paths {
+				if MatchGlob(pattern, p) {
+					return g.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// HasGroup reports whether a group with the given name is configured.
+func (c *Config) HasGroup(name string) bool {
+	for _, g := range c.Groups {
+		if g.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupNames returns the configured group names in config order.
+func (c *Config) GroupNames() []string {
+	names := make([]string, len(c.Groups))
+	for i, g := range c.Groups {
+		names[i] = g.Name
+	}
+	return names
+}
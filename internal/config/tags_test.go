@@ -0,0 +1,62 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTagUntag(t *testing.T) {
+	cfg := &Config{}
+
+	added := cfg.Tag("/projects/foo", "acme", "typo3", "acme")
+	if !slices.Equal(added, []string{"acme", "typo3"}) {
+		t.Errorf("expected [acme typo3] added, got %v", added)
+	}
+	if added := cfg.Tag("/projects/foo", "acme"); len(added) != 0 {
+		t.Errorf("re-adding a tag should be a no-op, got %v", added)
+	}
+
+	if n := cfg.Untag("/projects/foo", "acme"); n != 1 {
+		t.Errorf("expected 1 tag removed, got %d", n)
+	}
+	if got := cfg.TagsFor("/projects/foo"); !slices.Equal(got, []string{"typo3"}) {
+		t.Errorf("expected [typo3], got %v", got)
+	}
+
+	if n := cfg.Untag("/projects/foo"); n != 1 {
+		t.Errorf("expected all remaining tags removed, got %d", n)
+	}
+	if _, ok := cfg.Tags["/projects/foo"]; ok {
+		t.Error("untagged project should be removed from the tag map")
+	}
+}
+
+func TestTagsForAliases(t *testing.T) {
+	cfg := &Config{}
+	cfg.Tag("/home/me/x", "a")
+	cfg.Tag("/Users/me/x", "a", "b")
+
+	if got := cfg.TagsFor("/home/me/x", "/Users/me/x"); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("expected combined tags [a b], got %v", got)
+	}
+}
+
+func TestGroupFor(t *testing.T) {
+	cfg := &Config{Groups: []Group{
+		{Name: "acme", Paths: []string{"/src/clients/acme/**"}},
+		{Name: "clients", Paths: []string{"/src/clients/**"}},
+	}}
+
+	if got := cfg.GroupFor("/src/clients/acme/site"); got != "acme" {
+		t.Errorf("expected first matching group acme, got %q", got)
+	}
+	if got := cfg.GroupFor("/src/clients/globex/app"); got != "clients" {
+		t.Errorf("expected clients, got %q", got)
+	}
+	if got := cfg.GroupFor("/src/private/blog"); got != "" {
+		t.Errorf("expected no group, got %q", got)
+	}
+	if !cfg.HasGroup("acme") || cfg.HasGroup("nope") {
+		t.Error("HasGroup returned unexpected result")
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Wake-up events for snoozed acknowledgements.
+const (
+	WakeOnPrompts = "prompts" // new prompts in the project
+	WakeOnDirty   = "dirty"   // the repository became dirty
+	WakeOnRemote  = "remote"  // a remote-tracking branch moved (after a fetch)
+	WakeOnBranch  = "branch"  // a new local branch was created
+)
+
+// WakeEvents lists all supported wake-up events.
+var WakeEvents = []string{WakeOnPrompts, WakeOnDirty, WakeOnRemote, WakeOnBranch}
+
+// AckSnapshot captures the project state that wake-up events compare against.
+type AckSnapshot struct {
+	LastActivity time.Time `json:"lastActivity"`
+	Dirty        bool      `json:"dirty"`
+	// RemoteHeads is a fingerprint of all remote-tracking refs.
+	RemoteHeads string   `json:"remoteHeads,omitempty"`
+	Branches    []string `json:"branches,omitempty"`
+}
+
+// ValidateWakeOn checks that all events are supported.
+func ValidateWakeOn(events []string) error {
+	for _, e := range events {
+		if !slices.Contains(WakeEvents, e) {
+			return fmt.Errorf("unknown wake-up event %q (use %s)", e, strings.Join(WakeEvents, ", "))
+		}
+	}
+	return nil
+}
+
+// WokenBy compares the current project state with the snapshot taken at ack
+// time and returns the first WakeOn event that happened, or "".
+func (e AckEntry) WokenBy(current AckSnapshot) string {
+	if e.Snapshot == nil {
+		return ""
+	}
+	snap := e.Snapshot
+	for _, event := range e.WakeOn {
+		switch event {
+		case WakeOnPrompts:
+			if current.LastActivity.After(snap.LastActivity) {
+				return event
+			}
+		case WakeOnDirty:
+			if current.Dirty && !snap.Dirty {
+				return event
+			}
+		case WakeOnRemote:
+			if current.RemoteHeads != "" && current.RemoteHeads != snap.RemoteHeads {
+				return event
+			}
+		case WakeOnBranch:
+			for _, b := range current.Branches {
+				if !slices.Contains(snap.Branches, b) {
+					return event
+				}
+			}
+		}
+	}
+	return ""
+}
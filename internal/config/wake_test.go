@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWokenBy(t *testing.T) {
+	now := time.Now()
+	snap := &AckSnapshot{
+		LastActivity: now.Add(-time.Hour),
+		Dirty:        false,
+		RemoteHeads:  "abc",
+		Branches:     []string{"main"},
+	}
+
+	tests := []struct {
+		name    string
+		wakeOn  []string
+		current AckSnapshot
+		want    string
+	}{
+		{"unchanged", WakeEvents, *snap, ""},
+		{"new prompts", []string{WakeOnPrompts}, AckSnapshot{LastActivity: now, RemoteHeads: "abc", Branches: []string{"main"}}, WakeOnPrompts},
+		{"prompts not watched", []string{WakeOnDirty}, AckSnapshot{LastActivity: now}, ""},
+		{"dirty", []string{WakeOnDirty}, AckSnapshot{Dirty: true}, WakeOnDirty},
+		{"remote moved", []string{WakeOnRemote}, AckSnapshot{RemoteHeads: "def"}, WakeOnRemote},
+		{"remote unknown", []string{WakeOnRemote}, AckSnapshot{}, ""},
+		{"new branch", []string{WakeOnBranch}, AckSnapshot{Branches: []string{"main", "feature/x"}}, WakeOnBranch},
+		{"branch deleted", []string{WakeOnBranch}, AckSnapshot{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := AckEntry{WakeOn: tt.wakeOn, Snapshot: snap}
+			if got := e.WokenBy(tt.current); got != tt.want {
+				t.Errorf("WokenBy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchAckWakesSnooze(t *testing.T) {
+	now := time.Now()
+	cfg := &Config{}
+	cfg.Snooze("/projects/foo", nil, []string{WakeOnPrompts}, &AckSnapshot{LastActivity: now.Add(-time.Hour)})
+
+	quiet := AckSubject{Paths: []string{"/projects/foo"}, State: func() AckSnapshot {
+		return AckSnapshot{LastActivity: now.Add(-time.Hour)}
+	}}
+	if m := cfg.MatchAck(quiet); !m.Acknowledged {
+		t.Errorf("snooze without new activity should hold, got %+v", m)
+	}
+
+	active := AckSubject{Paths: []string{"/projects/foo"}, State: func() AckSnapshot {
+		return AckSnapshot{LastActivity: now}
+	}}
+	if m := cfg.MatchAck(active); m.Acknowledged || m.WokenBy != WakeOnPrompts {
+		t.Errorf("new prompts should wake the snooze, got %+v", m)
+	}
+
+	if err := ValidateWakeOn([]string{"prompts", "nope"}); err == nil {
+		t.Error("unknown wake-up event should be rejected")
+	}
+}
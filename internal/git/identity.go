@@ -0,0 +1,80 @@
+package git
+
+import (
+	"sort"
+	"strings"
+)
+
+// RemoteURL returns the normalized URL of the repository's origin remote,
+// falling back to the first configured remote. Returns "" if there is none.
+func RemoteURL(path string) string {
+	return Exec.RemoteURL(path)
+}
+
+// RemoteURL is like the package-level RemoteURL, running git through r.
+func (r Runner) RemoteURL(path string) string {
+	if url, err := r(path, "config", "--get", "remote.origin.url"); err == nil {
+		if u := strings.TrimSpace(url); u != "" {
+			return NormalizeRemote(u)
+		}
+	}
+
+	remotes, err := r(path, "remote")
+	if err != nil {
+		return ""
+	}
+	for _, name := range strings.Fields(remotes) {
+		if url, err := r(path, "config", "--get", "remote."+name+".url"); err == nil {
+			if u := strings.TrimSpace(url); u != "" {
+				return NormalizeRemote(u)
+			}
+		}
+	}
+	return ""
+}
+
+// RootCommit returns the hash of the repository's root commit, or "" if the
+// directory is not a repository or has no commits. Histories with several
+// roots resolve to the lexically smallest hash so the result is stable.
+func RootCommit(path string) string {
+	return Exec.RootCommit(path)
+}
+
+// RootCommit is like the package-level RootCommit, running git through r.
+func (r Runner) RootCommit(path string) string {
+	out, err := r(path, "rev-list", "--max-parents=0", "HEAD")
+	if err != nil {
+		return ""
+	}
+	roots := strings.Fields(out)
+	if len(roots) == 0 {
+		return ""
+	}
+	sort.Strings(roots)
+	return roots[0]
+}
+
+// NormalizeRemote reduces a remote URL to "host/owner/repo" so that SSH and
+// HTTPS clones of the same repository compare equal.
+func NormalizeRemote(url string) string {
+	u := strings.TrimSpace(url)
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+	} else if i := strings.Index(u, ":"); i >= 0 && !strings.Contains(u[:i], "/") {
+		// scp-like syntax: git@host:owner/repo
+		u = u[:i] + "/" + u[i+1:]
+	}
+	if i := strings.Index(u, "@"); i >= 0 && i < strings.Index(u+"/", "/") {
+		u = u[i+1:]
+	}
+
+	host, rest, _ := strings.Cut(u, "/")
+	if h, _, ok := strings.Cut(host, ":"); ok {
+		host = h
+	}
+	rest = strings.TrimSuffix(strings.TrimSuffix(rest, "/"), ".git")
+	if rest == "" {
+		return strings.ToLower(host)
+	}
+	return strings.ToLower(host) + "/" + rest
+}
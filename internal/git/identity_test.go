@@ -0,0 +1,67 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeRemote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"git@github.com:dkd-dobberkau/squirrel.git", "github.com/dkd-dobberkau/squirrel"},
+		{"https://github.com/dkd-dobberkau/squirrel.git", "github.com/dkd-dobberkau/squirrel"},
+		{"https://user@GitHub.com/dkd-dobberkau/squirrel", "github.com/dkd-dobberkau/squirrel"},
+		{"ssh://git@gitlab.example.com:2222/team/app.git", "gitlab.example.com/team/app"},
+		{"https://github.com/dkd-dobberkau/squirrel/", "github.com/dkd-dobberkau/squirrel"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeRemote(tt.input); got != tt.expected {
+			t.Errorf("NormalizeRemote(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestRemoteURLAndRootCommit(t *testing.T) {
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Run()
+	}
+	run("init")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test")
+	os.WriteFile(filepath.Join(dir, "file.txt"), []byte("hello"), 0644)
+	run("add", ".")
+	run("commit", "-m", "init")
+
+	if got := RemoteURL(dir); got != "" {
+		t.Errorf("expected no remote, got %q", got)
+	}
+
+	run("remote", "add", "origin", "git@github.com:test/project.git")
+	if got := RemoteURL(dir); got != "github.com/test/project" {
+		t.Errorf("expected normalized origin URL, got %q", got)
+	}
+
+	if got := RootCommit(dir); len(got) != 40 {
+		t.Errorf("expected a full commit hash, got %q", got)
+	}
+}
+
+func TestIdentity_NotARepo(t *testing.T) {
+	dir := t.TempDir()
+
+	if got := RemoteURL(dir); got != "" {
+		t.Errorf("expected empty remote for non-repo, got %q", got)
+	}
+	if got := RootCommit(dir); got != "" {
+		t.Errorf("expected empty root commit for non-repo, got %q", got)
+	}
+}
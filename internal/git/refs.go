@@ -0,0 +1,39 @@
+package git
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+)
+
+// Branches returns the names of all local branches, or nil if the directory
+// is not a repository.
+func Branches(path string) []string {
+	return Exec.Branches(path)
+}
+
+// Branches is like the package-level Branches, running git through r.
+func (r Runner) Branches(path string) []string {
+	out, err := r(path, "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(out)
+}
+
+// RemoteHeads returns a fingerprint of all remote-tracking refs. It changes
+// whenever a fetch brings in new commits or branches. Returns "" if there are
+// no remote-tracking refs. No network access is performed.
+func RemoteHeads(path string) string {
+	return Exec.RemoteHeads(path)
+}
+
+// RemoteHeads is like the package-level RemoteHeads, running git through r.
+func (r Runner) RemoteHeads(path string) string {
+	out, err := r(path, "for-each-ref", "--format=%(objectname) %(refname)", "refs/remotes")
+	if err != nil || strings.TrimSpace(out) == "" {
+		return ""
+	}
+	sum := sha1.Sum([]byte(out))
+	return hex.EncodeToString(sum[:])
+}
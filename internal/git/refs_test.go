@@ -0,0 +1,48 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBranchesAndRemoteHeads(t *testing.T) {
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Run()
+	}
+	run("init")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test")
+	os.WriteFile(filepath.Join(dir, "file.txt"), []byte("hello"), 0644)
+	run("add", ".")
+	run("commit", "-m", "init")
+	run("branch", "feature/x")
+
+	branches := Branches(dir)
+	if len(branches) != 2 || !slices.Contains(branches, "feature/x") {
+		t.Errorf("expected default branch and feature/x, got %v", branches)
+	}
+
+	if got := RemoteHeads(dir); got != "" {
+		t.Errorf("expected no remote heads, got %q", got)
+	}
+
+	run("update-ref", "refs/remotes/origin/main", "HEAD")
+	first := RemoteHeads(dir)
+	if first == "" {
+		t.Fatal("expected remote heads fingerprint")
+	}
+
+	os.WriteFile(filepath.Join(dir, "file.txt"), []byte("changed"), 0644)
+	run("commit", "-am", "second")
+	run("update-ref", "refs/remotes/origin/main", "HEAD")
+	if RemoteHeads(dir) == first {
+		t.Error("fingerprint should change when a remote-tracking ref moves")
+	}
+}
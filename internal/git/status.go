@@ -14,22 +14,34 @@ type RepoStatus struct {
 	UncommittedFiles int    `json:"uncommittedFiles"`
 }
 
+// Runner runs git with args in the directory path and returns its standard
+// output. It lets tests replace the git binary.
+type Runner func(path string, args ...string) (string, error)
+
+// Exec is the Runner that runs the git binary.
+var Exec Runner = gitCommand
+
 // CheckStatus checks the git status of a directory using native git commands.
 // This respects .gitignore, .git/info/exclude, and the global gitignore.
 // Returns a zero-value RepoStatus with IsRepo=false if the directory is not a git repo.
 func CheckStatus(path string) (RepoStatus, error) {
-	if !isGitRepo(path) {
+	return Exec.CheckStatus(path)
+}
+
+// CheckStatus is like the package-level CheckStatus, running git through r.
+func (r Runner) CheckStatus(path string) (RepoStatus, error) {
+	if !r.IsRepo(path) {
 		return RepoStatus{IsRepo: false}, nil
 	}
 
 	status := RepoStatus{IsRepo: true}
 
-	if branch, err := gitCommand(path, "rev-parse", "--abbrev-ref", "HEAD"); err == nil {
+	if branch, err := r(path, "rev-parse", "--abbrev-ref", "HEAD"); err == nil {
 		status.Branch = strings.TrimSpace(branch)
 		status.IsFeatureBranch = isFeatureBranch(status.Branch)
 	}
 
-	if porcelain, err := gitCommand(path, "status", "--porcelain"); err == nil {
+	if porcelain, err := r(path, "status", "--porcelain"); err == nil {
 		lines := strings.Split(strings.TrimSpace(porcelain), "\n")
 		for _, line := range lines {
 			if line != "" {
@@ -42,9 +54,15 @@ func CheckStatus(path string) (RepoStatus, error) {
 	return status, nil
 }
 
-func isGitRepo(path string) bool {
-	cmd := exec.Command("git", "-C", path, "rev-parse", "--git-dir")
-	return cmd.Run() == nil
+// IsRepo reports whether path is inside a git repository.
+func IsRepo(path string) bool {
+	return Exec.IsRepo(path)
+}
+
+// IsRepo is like the package-level IsRepo, running git through r.
+func (r Runner) IsRepo(path string) bool {
+	_, err := r(path, "rev-parse", "--git-dir")
+	return err == nil
 }
 
 func gitCommand(path string, args ...string) (string, error) {
@@ -150,3 +150,33 @@ func TestCheckStatus_NotARepo(t *testing.T) {
 		t.Error("expected IsRepo=false for non-git directory")
 	}
 }
+
+func TestRunnerCheckStatus(t *testing.T) {
+	var calls [][]string
+	fake := Runner(func(path string, args ...string) (string, error) {
+		calls = append(calls, append([]string{path}, args...))
+		switch args[0] {
+		case "rev-parse":
+			if args[1] == "--abbrev-ref" {
+				return "feature/x\n", nil
+			}
+			return ".git\n", nil
+		case "status":
+			return " M a.go\n?? b.go\n", nil
+		}
+		return "", nil
+	})
+
+	status, err := fake.CheckStatus("/src/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.IsRepo || !status.IsDirty || status.UncommittedFiles != 2 || status.Branch != "feature/x" || !status.IsFeatureBranch {
+		t.Errorf("unexpected status %+v", status)
+	}
+	for _, c := range calls {
+		if c[0] != "/src/app" {
+			t.Errorf("git ran in %q, want /src/app", c[0])
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package i18n
+
+// Messages are keyed by area and purpose. Keys ending in ".one" are the
+// singular forms used by N.
+
+var en = map[string]string{
+	"title.status": "Squirrel - Your forgotten nuts",
+	"title.detail": "Squirrel - Project detail",
+	"empty":        "No projects found in the selected period.",
+
+	"section.openWork":       "Open work",
+	"section.recentActivity": "Recent activity",
+	"section.sleeping":       "Sleeping projects",
+	"section.acknowledged":   "Acknowledged",
+	"section.ignored":        "Ignored",
+	"section.project":        "Project",
+	"section.activity":       "Activity",
+	"section.notes":          "Notes",
+	"section.sessions":       "Sessions",
+	"section.prompts":        "Recent prompts",
+	"section.todos":          "TODOs",
+
+	"group.none": "ungrouped",
+
+	"project.prompts":      "%4d prompts",
+	"project.prompts.one":  "%4d prompt",
+	"project.reminder":     "Reminder: %s",
+	"project.woken":        "woke up: %s",
+	"project.uncommitted":  "%d uncommitted",
+	"project.branch":       "branch: %s",
+	"project.inactive":     "%d days inactive",
+	"project.inactive.one": "%d day inactive",
+	"project.rule":         "rule: %s",
+	"project.ignored":      "ignored: %s",
+
+	"label.path":          "Path",
+	"label.name":          "Name",
+	"label.host":          "Host",
+	"label.profile":       "Profile",
+	"label.alias":         "Alias",
+	"label.group":         "Group",
+	"label.tags":          "Tags",
+	"label.branch":        "Branch",
+	"label.gitStatus":     "Git status",
+	"label.score":         "Score",
+	"label.prompts":       "Prompts",
+	"label.first":         "First",
+	"label.last":          "Last",
+	"label.inactive":      "Inactive",
+	"label.firstActivity": "First activity",
+	"label.lastActivity":  "Last activity",
+	"label.project":       "Project",
+	"label.git":           "Git",
+	"label.note":          "Note",
+	"label.activity":      "Activity",
+	"label.details":       "Details",
+
+	"detail.days":     "%d days",
+	"detail.days.one": "%d day",
+	"git.clean":       "clean",
+	"note.reminder":   "(reminder %s)",
+	"session.msgs":    "%d msgs",
+
+	"md.details":      "%s: %d sessions, %d TODOs",
+	"md.showSessions": "Show %d sessions",
+
+	"html.generated":    "Generated %s · activity heatmap of the last %d weeks",
+	"html.sessions":     "%d sessions",
+	"html.sessions.one": "%d session",
+	"html.todos":        "%d TODOs",
+	"html.todos.one":    "%d TODO",
+	"html.heat":         "%[2]s: %[1]d prompts",
+	"html.heat.one":     "%[2]s: %[1]d prompt",
+	"html.reportTitle":  "Squirrel - Report %s",
+
+	"timeline.title":       "Squirrel - Timeline",
+	"timeline.week":        "Week of %s",
+	"timeline.bucket":      "%s (%s)",
+	"timeline.prompts":     "%d prompts",
+	"timeline.prompts.one": "%d prompt",
+	"timeline.more":        "+%d more sessions",
+	"timeline.more.one":    "+%d more session",
+	"label.lastPrompt":     "Last prompt",
+	"label.sessions":       "Sessions",
+
+	"heatmap.title":    "Squirrel - Activity",
+	"heatmap.less":     "less",
+	"heatmap.more":     "more",
+	"heatmap.days":     "%d active days",
+	"heatmap.days.one": "%d active day",
+
+	"stats.title":             "Squirrel - Statistics",
+	"stats.range":             "%s - %s (%d days)",
+	"stats.prompts":           "Prompts",
+	"stats.promptsValue":      "%d (%.1f per day, %.1f per week)",
+	"stats.activeDays":        "Active days",
+	"stats.activeDaysValue":   "%d of %d",
+	"stats.longestStreak":     "Longest streak",
+	"stats.currentStreak":     "Current streak",
+	"stats.topHours":          "Busiest hours",
+	"stats.byHour":            "By hour",
+	"stats.sessions":          "Sessions started",
+	"stats.avgLength":         "Average length",
+	"stats.minutes":           "%.0f min",
+	"stats.avgMessages":       "Average messages",
+	"stats.focus":             "Focus",
+	"stats.projectsPerWeek":   "Projects per week",
+	"stats.contextSwitches":   "Context switches",
+	"stats.switchesValue":     "%d (%.0f%% of consecutive prompts)",
+	"stats.sleepingOpen":      "Asleep with open work",
+	"stats.sleepingOpenValue": "%d of %d sleeping projects (%.0f%%)",
+
+	"weekday.0": "Sun", "weekday.1": "Mon", "weekday.2": "Tue", "weekday.3": "Wed",
+	"weekday.4": "Thu", "weekday.5": "Fri", "weekday.6": "Sat",
+	"month.1": "January", "month.2": "February", "month.3": "March", "month.4": "April",
+	"month.5": "May", "month.6": "June", "month.7": "July", "month.8": "August",
+	"month.9": "September", "month.10": "October", "month.11": "November", "month.12": "December",
+
+	"rel.never":      "never",
+	"rel.now":        "just now",
+	"rel.minutes":    "%d min ago",
+	"rel.hours":      "%d hours ago",
+	"rel.hours.one":  "%d hour ago",
+	"rel.yesterday":  "yesterday",
+	"rel.days":       "%d days ago",
+	"rel.weeks":      "%d weeks ago",
+	"rel.weeks.one":  "%d week ago",
+	"rel.months":     "%d months ago",
+	"rel.months.one": "%d month ago",
+	"rel.years":      "%d years ago",
+	"rel.years.one":  "%d year ago",
+
+	"skill.language":        "Present the results to the user in English.",
+	"skill.recommendations": "Top 3 recommendations",
+	"skill.summary":         "You have X open work items, Y active projects and Z sleeping projects",
+	"skill.ask":             "Which project would you like to continue with?",
+	"skill.cd":              "Shall I switch to the project directory?",
+}
+
+var de = map[string]string{
+	"title.status": "Squirrel - Deine vergessenen Nuesse",
+	"title.detail": "Squirrel - Projekt-Detail",
+	"empty":        "Keine Projekte im gewaehlten Zeitraum gefunden.",
+
+	"section.openWork":       "Offene Baustellen",
+	"section.recentActivity": "Letzte Aktivitaet",
+	"section.sleeping":       "Schlafende Projekte",
+	"section.acknowledged":   "Erledigt",
+	"section.ignored":        "Ignoriert",
+	"section.project":        "Projekt",
+	"section.activity":       "Aktivitaet",
+	"section.notes":          "Notizen",
+	"section.sessions":       "Sessions",
+	"section.prompts":        "Letzte Prompts",
+	"section.todos":          "TODOs",
+
+	"group.none": "ohne Gruppe",
+
+	"project.prompts":      "%4d Prompts",
+	"project.prompts.one":  "%4d Prompt",
+	"project.reminder":     "Erinnerung: %s",
+	"project.woken":        "aufgewacht: %s",
+	"project.uncommitted":  "%d nicht committet",
+	"project.branch":       "Branch: %s",
+	"project.inactive":     "%d Tage inaktiv",
+	"project.inactive.one": "%d Tag inaktiv",
+	"project.rule":         "Regel: %s",
+	"project.ignored":      "ignoriert: %s",
+
+	"label.path":          "Pfad",
+	"label.name":          "Name",
+	"label.host":          "Host",
+	"label.profile":       "Profil",
+	"label.alias":         "Alias",
+	"label.group":         "Gruppe",
+	"label.tags":          "Tags",
+	"label.branch":        "Branch",
+	"label.gitStatus":     "Git-Status",
+	"label.score":         "Score",
+	"label.prompts":       "Prompts",
+	"label.first":         "Erste",
+	"label.last":          "Letzte",
+	"label.inactive":      "Inaktiv",
+	"label.firstActivity": "Erste Aktivitaet",
+	"label.lastActivity":  "Letzte Aktivitaet",
+	"label.project":       "Projekt",
+	"label.git":           "Git",
+	"label.note":          "Notiz",
+	"label.activity":      "Aktivitaet",
+	"label.details":       "Details",
+
+	"detail.days":     "%d Tage",
+	"detail.days.one": "%d Tag",
+	"git.clean":       "sauber",
+	"note.reminder":   "(Erinnerung %s)",
+	"session.msgs":    "%d Nachr.",
+
+	"md.details":      "%s: %d Sessions, %d TODOs",
+	"md.showSessions": "%d Sessions anzeigen",
+
+	"html.generated":    "Erstellt am %s · Aktivitaet der letzten %d Wochen",
+	"html.sessions":     "%d Sessions",
+	"html.sessions.one": "%d Session",
+	"html.todos":        "%d TODOs",
+	"html.todos.one":    "%d TODO",
+	"html.heat":         "%[2]s: %[1]d Prompts",
+	"html.heat.one":     "%[2]s: %[1]d Prompt",
+	"html.reportTitle":  "Squirrel - Bericht %s",
+
+	"timeline.title":       "Squirrel - Zeitleiste",
+	"timeline.week":        "Woche ab %s",
+	"timeline.bucket":      "%s (%s)",
+	"timeline.prompts":     "%d Prompts",
+	"timeline.prompts.one": "%d Prompt",
+	"timeline.more":        "+%d weitere Sessions",
+	"timeline.more.one":    "+%d weitere Session",
+	"label.lastPrompt":     "Letzter Prompt",
+	"label.sessions":       "Sessions",
+
+	"heatmap.title":    "Squirrel - Aktivitaet",
+	"heatmap.less":     "weniger",
+	"heatmap.more":     "mehr",
+	"heatmap.days":     "%d aktive Tage",
+	"heatmap.days.one": "%d aktiver Tag",
+
+	"stats.title":             "Squirrel - Statistik",
+	"stats.range":             "%s - %s (%d Tage)",
+	"stats.prompts":           "Prompts",
+	"stats.promptsValue":      "%d (%.1f pro Tag, %.1f pro Woche)",
+	"stats.activeDays":        "Aktive Tage",
+	"stats.activeDaysValue":   "%d von %d",
+	"stats.longestStreak":     "Laengste Serie",
+	"stats.currentStreak":     "Aktuelle Serie",
+	"stats.topHours":          "Aktivste Stunden",
+	"stats.byHour":            "Nach Stunde",
+	"stats.sessions":          "Begonnene Sessions",
+	"stats.avgLength":         "Durchschnittsdauer",
+	"stats.minutes":           "%.0f Min.",
+	"stats.avgMessages":       "Nachrichten im Schnitt",
+	"stats.focus":             "Fokus",
+	"stats.projectsPerWeek":   "Projekte pro Woche",
+	"stats.contextSwitches":   "Kontextwechsel",
+	"stats.switchesValue":     "%d (%.0f%% aufeinanderfolgender Prompts)",
+	"stats.sleepingOpen":      "Offen eingeschlafen",
+	"stats.sleepingOpenValue": "%d von %d schlafenden Projekten (%.0f%%)",
+
+	"weekday.0": "So", "weekday.1": "Mo", "weekday.2": "Di", "weekday.3": "Mi",
+	"weekday.4": "Do", "weekday.5": "Fr", "weekday.6": "Sa",
+	"month.1": "Januar", "month.2": "Februar", "month.3": "Maerz", "month.4": "April",
+	"month.5": "Mai", "month.6": "Juni", "month.7": "Juli", "month.8": "August",
+	"month.9": "September", "month.10": "Oktober", "month.11": "November", "month.12": "Dezember",
+
+	"rel.never":      "nie",
+	"rel.now":        "gerade eben",
+	"rel.minutes":    "vor %d Min.",
+	"rel.hours":      "vor %d Std.",
+	"rel.hours.one":  "vor %d Std.",
+	"rel.yesterday":  "gestern",
+	"rel.days":       "vor %d Tagen",
+	"rel.weeks":      "vor %d Wochen",
+	"rel.weeks.one":  "vor %d Woche",
+	"rel.months":     "vor %d Monaten",
+	"rel.months.one": "vor %d Monat",
+	"rel.years":      "vor %d Jahren",
+	"rel.years.one":  "vor %d Jahr",
+
+	"skill.language":        "Present the results to the user in German.",
+	"skill.recommendations": "Top 3 Empfehlungen",
+	"skill.summary":         "Du hast X offene Baustellen, Y aktive Projekte und Z schlafende Projekte",
+	"skill.ask":             "An welchem Projekt moechtest du weiterarbeiten?",
+	"skill.cd":              "Soll ich in das Projektverzeichnis wechseln?",
+}
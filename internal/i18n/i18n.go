@@ -0,0 +1,150 @@
+// Package i18n holds the message catalogue and locale-aware date formatting
+// for user-facing output.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Locale is a language with its messages and date layouts.
+type Locale struct {
+	Tag string
+	// Name is the language's English name, e.g. for telling the skill
+	// which language to answer in.
+	Name     string
+	messages map[string]string
+	// Date layouts for time.Format
+	date, dateTime, shortDate, shortDateTime string
+}
+
+var locales = map[string]*Locale{
+	"en": {Tag: "en", Name: "English", messages: en,
+		date: "Jan 2, 2006", dateTime: "Jan 2, 2006 15:04", shortDate: "Jan 02", shortDateTime: "Jan 02 15:04"},
+	"de": {Tag: "de", Name: "German", messages: de,
+		date: "02.01.2006", dateTime: "02.01.2006 15:04", shortDate: "02.01.", shortDateTime: "02.01. 15:04"},
+}
+
+// Fallback is used when no supported locale is requested.
+const Fallback = "en"
+
+var current = locales[Fallback]
+
+// Supported returns the tags of all available locales.
+func Supported() []string {
+	return []string{"de", "en"}
+}
+
+// Normalize reduces a locale like "de_DE.UTF-8" to a supported tag, or ""
+// if the language is not supported.
+func Normalize(s string) string {
+	lang := strings.ToLower(s)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	if slices.Contains(Supported(), lang) {
+		return lang
+	}
+	return ""
+}
+
+// Detect returns the locale requested by LC_ALL, LC_MESSAGES or LANG,
+// falling back to English.
+func Detect() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			if tag := Normalize(v); tag != "" {
+				return tag
+			}
+			// The first variable that is set wins, as in POSIX
+			return Fallback
+		}
+	}
+	return Fallback
+}
+
+// Set selects the locale used by T, N and the date functions.
+func Set(tag string) error {
+	l, ok := locales[Normalize(tag)]
+	if !ok {
+		return fmt.Errorf("unsupported language %q (use %s)", tag, strings.Join(Supported(), ", "))
+	}
+	current = l
+	return nil
+}
+
+// Current returns the selected locale.
+func Current() *Locale {
+	return current
+}
+
+// T returns the message for key in the current locale, formatted with args.
+// Missing messages fall back to English and then to the key itself.
+func T(key string, args ...any) string {
+	msg, ok := current.messages[key]
+	if !ok {
+		if msg, ok = en[key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// N is like T for messages that depend on a count: it uses key+".one" when
+// n is 1 and such a message exists. n is passed as the first argument.
+func N(key string, n int, args ...any) string {
+	if n == 1 {
+		if _, ok := current.messages[key+".one"]; ok {
+			key += ".one"
+		}
+	}
+	return T(key, append([]any{n}, args...)...)
+}
+
+// Date formats t as a full date, e.g. "Mar 1, 2026" or "01.03.2026".
+func Date(t time.Time) string { return t.Format(current.date) }
+
+// DateTime formats t as date and time.
+func DateTime(t time.Time) string { return t.Format(current.dateTime) }
+
+// ShortDate formats t as day and month for compact listings.
+func ShortDate(t time.Time) string { return t.Format(current.shortDate) }
+
+// ShortDateTime formats t as day, month and time for compact listings.
+func ShortDateTime(t time.Time) string { return t.Format(current.shortDateTime) }
+
+// Weekday returns the abbreviated name of t's weekday.
+func Weekday(t time.Time) string { return T(fmt.Sprintf("weekday.%d", t.Weekday())) }
+
+// Month returns the name of t's month.
+func Month(t time.Time) string { return T(fmt.Sprintf("month.%d", t.Month())) }
+
+// RelTime describes t relative to now, e.g. "3 days ago".
+func RelTime(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case t.IsZero():
+		return T("rel.never")
+	case d < time.Minute:
+		return T("rel.now")
+	case d < time.Hour:
+		return N("rel.minutes", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return N("rel.hours", int(d.Hours()))
+	case d < 48*time.Hour:
+		return T("rel.yesterday")
+	case d < 14*24*time.Hour:
+		return N("rel.days", int(d.Hours()/24))
+	case d < 60*24*time.Hour:
+		return N("rel.weeks", int(d.Hours()/24/7))
+	case d < 365*24*time.Hour:
+		return N("rel.months", int(d.Hours()/24/30))
+	}
+	return N("rel.years", int(d.Hours()/24/365))
+}
@@ -0,0 +1,125 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+// use switches to tag for the duration of the test.
+func use(t *testing.T, tag string) {
+	t.Helper()
+	prev := current
+	if err := Set(tag); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { current = prev })
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"de":          "de",
+		"de_DE.UTF-8": "de",
+		"de-AT":       "de",
+		"EN_us":       "en",
+		"C":           "",
+		"fr_FR":       "",
+	}
+	for in, want := range tests {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+	if got := Detect(); got != "de" {
+		t.Errorf("Detect() with LANG=de_DE = %q", got)
+	}
+	t.Setenv("LC_ALL", "C")
+	if got := Detect(); got != "en" {
+		t.Errorf("Detect() with LC_ALL=C = %q, want LC_ALL to win", got)
+	}
+}
+
+func TestSetRejectsUnsupported(t *testing.T) {
+	if err := Set("fr"); err == nil {
+		t.Error("Set(fr) succeeded")
+	}
+}
+
+func TestRelTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago    time.Duration
+		en, de string
+	}{
+		{10 * time.Second, "just now", "gerade eben"},
+		{5 * time.Minute, "5 min ago", "vor 5 Min."},
+		{time.Hour, "1 hour ago", "vor 1 Std."},
+		{3 * time.Hour, "3 hours ago", "vor 3 Std."},
+		{30 * time.Hour, "yesterday", "gestern"},
+		{5 * 24 * time.Hour, "5 days ago", "vor 5 Tagen"},
+		{21 * 24 * time.Hour, "3 weeks ago", "vor 3 Wochen"},
+		{90 * 24 * time.Hour, "3 months ago", "vor 3 Monaten"},
+		{800 * 24 * time.Hour, "2 years ago", "vor 2 Jahren"},
+	}
+	for _, tag := range []string{"en", "de"} {
+		use(t, tag)
+		for _, tt := range tests {
+			want := tt.en
+			if tag == "de" {
+				want = tt.de
+			}
+			if got := RelTime(now.Add(-tt.ago), now); got != want {
+				t.Errorf("%s: RelTime(-%v) = %q, want %q", tag, tt.ago, got, want)
+			}
+		}
+	}
+	if got := RelTime(time.Time{}, now); got != "nie" {
+		t.Errorf("RelTime(zero) = %q", got)
+	}
+}
+
+func TestDates(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 9, 5, 0, 0, time.UTC)
+	use(t, "en")
+	if got := DateTime(ts); got != "Mar 1, 2026 09:05" {
+		t.Errorf("en DateTime = %q", got)
+	}
+	use(t, "de")
+	if got := DateTime(ts); got != "01.03.2026 09:05" {
+		t.Errorf("de DateTime = %q", got)
+	}
+	if got := ShortDate(ts); got != "01.03." {
+		t.Errorf("de ShortDate = %q", got)
+	}
+}
+
+func TestN(t *testing.T) {
+	use(t, "en")
+	if got := N("project.inactive", 1); got != "1 day inactive" {
+		t.Errorf("N(1) = %q", got)
+	}
+	if got := N("project.inactive", 4); got != "4 days inactive" {
+		t.Errorf("N(4) = %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("T(missing) = %q", got)
+	}
+}
+
+func TestCataloguesMatch(t *testing.T) {
+	for key := range en {
+		if _, ok := de[key]; !ok {
+			t.Errorf("de is missing %q", key)
+		}
+	}
+	for key := range de {
+		if _, ok := en[key]; !ok {
+			t.Errorf("en is missing %q", key)
+		}
+	}
+}
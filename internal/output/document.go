@@ -0,0 +1,333 @@
+package output
+
+import (
+	"math"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+// SchemaVersion is the version of the JSON output documents. It is bumped
+// whenever a field is removed, renamed or changes its meaning; adding
+// optional fields keeps the version.
+const SchemaVersion = 1
+
+// StatusDocument is the JSON output of status and stash.
+type StatusDocument struct {
+	SchemaVersion  int       `json:"schemaVersion" doc:"Version of this document format"`
+	OpenWork       []Project `json:"openWork" doc:"Projects with uncommitted changes, feature branches or due reminders"`
+	RecentActivity []Project `json:"recentActivity" doc:"Projects active in the last three days"`
+	Sleeping       []Project `json:"sleeping" doc:"Projects without recent activity"`
+	Acknowledged   []Project `json:"acknowledged" doc:"Projects the user acknowledged"`
+	Ignored        []Project `json:"ignored,omitempty" doc:"Ignored projects, only with --include-ignored"`
+	Groups         []string  `json:"groups,omitempty" doc:"Project groups present in the result, in config order"`
+}
+
+// Project is a project as exposed in JSON output.
+type Project struct {
+	Path             string    `json:"path"`
+	ShortName        string    `json:"shortName"`
+	Aliases          []string  `json:"aliases,omitempty" doc:"Other paths merged into this project"`
+	Host             string    `json:"host,omitempty" doc:"Machine the project was seen on, for projects from other hosts"`
+	Profiles         []string  `json:"profiles,omitempty" doc:"Claude profiles the project was used in"`
+	Tags             []string  `json:"tags,omitempty"`
+	Group            string    `json:"group,omitempty"`
+	Notes            []Note    `json:"notes,omitempty" doc:"User notes, newest first"`
+	ReminderDue      bool      `json:"reminderDue,omitempty"`
+	AckedBy          string    `json:"ackedBy,omitempty" doc:"Ack rule that matched"`
+	IgnoredBy        string    `json:"ignoredBy,omitempty" doc:"Ignore entry that matched"`
+	WokenBy          string    `json:"wokenBy,omitempty" doc:"Event that ended a snooze"`
+	PromptCount      int       `json:"promptCount" doc:"Prompts within the time window"`
+	LastActivity     time.Time `json:"lastActivity"`
+	FirstActivity    time.Time `json:"firstActivity"`
+	LastPrompt       string    `json:"lastPrompt"`
+	SessionCount     int       `json:"sessionCount"`
+	LatestSummary    string    `json:"latestSummary,omitempty" doc:"Summary of the most recent session"`
+	LatestBranch     string    `json:"latestBranch,omitempty" doc:"Git branch of the most recent session"`
+	Todos            []Todo    `json:"todos,omitempty" doc:"TODOs found in session messages, with --deep"`
+	GitDirty         bool      `json:"gitDirty"`
+	GitBranch        string    `json:"gitBranch"`
+	UncommittedFiles int       `json:"uncommittedFiles"`
+	DaysSinceActive  int       `json:"daysSinceActive"`
+	IsOpenWork       bool      `json:"isOpenWork"`
+	Score            float64   `json:"score"`
+	Sessions         []Session `json:"sessions,omitempty" doc:"Sessions, only in project detail output"`
+}
+
+// Note is a user note in JSON output.
+type Note struct {
+	Text      string     `json:"text"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RemindAt  *time.Time `json:"remindAt,omitempty"`
+}
+
+// Todo is a TODO extracted from session messages.
+type Todo struct {
+	Text      string `json:"text"`
+	Source    string `json:"source" doc:"TODO, FIXME, HACK or XXX"`
+	SessionID string `json:"sessionId"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Session is a Claude Code session in project detail and timeline output.
+type Session struct {
+	ID           string `json:"id"`
+	Summary      string `json:"summary,omitempty"`
+	FirstPrompt  string `json:"firstPrompt,omitempty"`
+	MessageCount int    `json:"messageCount"`
+	Created      string `json:"created"`
+	Modified     string `json:"modified"`
+	GitBranch    string `json:"gitBranch,omitempty"`
+}
+
+// Prompt is a prompt from the history.
+type Prompt struct {
+	Text string    `json:"text"`
+	Time time.Time `json:"time"`
+}
+
+// ProjectDocument is the JSON output of the project command.
+type ProjectDocument struct {
+	SchemaVersion int      `json:"schemaVersion" doc:"Version of this document format"`
+	Project       Project  `json:"project"`
+	RecentPrompts []Prompt `json:"recentPrompts" doc:"Most recent prompts, newest first"`
+	Acknowledged  bool     `json:"acknowledged" doc:"Whether an acknowledgement or ack rule covers the project"`
+}
+
+// TimelineDocument is the JSON output of timeline.
+type TimelineDocument struct {
+	SchemaVersion int              `json:"schemaVersion" doc:"Version of this document format"`
+	By            string           `json:"by" doc:"Bucket size: day, week or month"`
+	Buckets       []TimelineBucket `json:"buckets" doc:"Buckets with activity, newest first"`
+}
+
+// TimelineBucket is one day, week or month of activity.
+type TimelineBucket struct {
+	Start    time.Time         `json:"start"`
+	End      time.Time         `json:"end" doc:"Start of the next bucket"`
+	Prompts  int               `json:"prompts"`
+	Projects []TimelineProject `json:"projects" doc:"Active projects, most recent first"`
+}
+
+// TimelineProject is a project's activity within one bucket.
+type TimelineProject struct {
+	Path        string    `json:"path"`
+	ShortName   string    `json:"shortName"`
+	Host        string    `json:"host,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Group       string    `json:"group,omitempty"`
+	Prompts     int       `json:"prompts" doc:"Prompts within the bucket"`
+	FirstPrompt time.Time `json:"firstPrompt"`
+	LastPrompt  time.Time `json:"lastPrompt"`
+	Sessions    []Session `json:"sessions" doc:"Sessions last modified within the bucket, newest first"`
+}
+
+// StatsDocument is the JSON output of stats. Averages and rates are
+// rounded to two decimals.
+type StatsDocument struct {
+	SchemaVersion      int         `json:"schemaVersion" doc:"Version of this document format"`
+	Since              time.Time   `json:"since"`
+	Until              time.Time   `json:"until" doc:"End of the range, exclusive"`
+	Days               int         `json:"days" doc:"Calendar days in the range"`
+	Prompts            int         `json:"prompts"`
+	PromptsPerDay      float64     `json:"promptsPerDay"`
+	PromptsPerWeek     float64     `json:"promptsPerWeek"`
+	ActiveDays         int         `json:"activeDays" doc:"Days with at least one prompt"`
+	LongestStreak      Streak      `json:"longestStreak"`
+	CurrentStreak      int         `json:"currentStreak" doc:"Consecutive active days up to the end of the range"`
+	PromptsByHour      []int       `json:"promptsByHour" doc:"Prompts per hour of the day, 0 to 23, local time"`
+	TopHours           []int       `json:"topHours" doc:"Busiest hours of the day, busiest first"`
+	Sessions           int         `json:"sessions" doc:"Sessions started in the range"`
+	AvgSessionMinutes  float64     `json:"avgSessionMinutes"`
+	AvgSessionMessages float64     `json:"avgSessionMessages"`
+	ProjectsPerWeek    []WeekCount `json:"projectsPerWeek" doc:"Distinct projects per week, weeks start on Monday"`
+	AvgProjectsPerWeek float64     `json:"avgProjectsPerWeek"`
+	ContextSwitches    int         `json:"contextSwitches" doc:"Consecutive prompts on the same day that went to different projects"`
+	SwitchRate         float64     `json:"switchRate" doc:"Share of consecutive same-day prompts that switched project, 0 to 1"`
+	Sleeping           int         `json:"sleeping" doc:"Projects without activity for more than three days"`
+	SleepingOpen       int         `json:"sleepingOpen" doc:"Sleeping projects left with open work"`
+	SleepingOpenShare  float64     `json:"sleepingOpenShare" doc:"sleepingOpen / sleeping, 0 to 1"`
+}
+
+// Streak is a run of consecutive active days.
+type Streak struct {
+	Days  int        `json:"days"`
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// WeekCount counts projects in the week starting at Start.
+type WeekCount struct {
+	Start    time.Time `json:"start"`
+	Projects int       `json:"projects"`
+}
+
+// NewStatusDocument converts categorized projects to their output form.
+func NewStatusDocument(data analyzer.CategorizedProjects) StatusDocument {
+	return StatusDocument{
+		SchemaVersion:  SchemaVersion,
+		OpenWork:       newProjects(data.OpenWork, true),
+		RecentActivity: newProjects(data.RecentActivity, true),
+		Sleeping:       newProjects(data.Sleeping, true),
+		Acknowledged:   newProjects(data.Acknowledged, true),
+		Ignored:        newProjects(data.Ignored, false),
+		Groups:         data.Groups,
+	}
+}
+
+// NewProjectDocument converts a project detail to its output form.
+func NewProjectDocument(detail ProjectDetail) ProjectDocument {
+	doc := ProjectDocument{
+		SchemaVersion: SchemaVersion,
+		Project:       newProject(detail.Project),
+		RecentPrompts: []Prompt{},
+		Acknowledged:  detail.Acknowledged,
+	}
+	for _, s := range detail.Project.Sessions {
+		doc.Project.Sessions = append(doc.Project.Sessions, newSession(s))
+	}
+	for _, e := range detail.RecentPrompts {
+		doc.RecentPrompts = append(doc.RecentPrompts, Prompt{Text: e.Display, Time: time.UnixMilli(e.Timestamp)})
+	}
+	return doc
+}
+
+// NewTimelineDocument converts timeline buckets to their output form.
+func NewTimelineDocument(buckets []analyzer.TimelineBucket, by string) TimelineDocument {
+	doc := TimelineDocument{
+		SchemaVersion: SchemaVersion,
+		By:            by,
+		Buckets:       make([]TimelineBucket, len(buckets)),
+	}
+	for i, b := range buckets {
+		out := TimelineBucket{
+			Start:    b.Start,
+			End:      b.End,
+			Prompts:  b.Prompts,
+			Projects: make([]TimelineProject, len(b.Projects)),
+		}
+		for j, tp := range b.Projects {
+			p := TimelineProject{
+				Path:        tp.Project.Path,
+				ShortName:   tp.Project.ShortName,
+				Host:        tp.Project.Host,
+				Tags:        tp.Project.Tags,
+				Group:       tp.Project.Group,
+				Prompts:     tp.Prompts,
+				FirstPrompt: tp.FirstPrompt,
+				LastPrompt:  tp.LastPrompt,
+				Sessions:    []Session{},
+			}
+			for _, s := range tp.Sessions {
+				p.Sessions = append(p.Sessions, newSession(s))
+			}
+			out.Projects[j] = p
+		}
+		doc.Buckets[i] = out
+	}
+	return doc
+}
+
+// NewStatsDocument converts statistics to their output form.
+func NewStatsDocument(st analyzer.Stats) StatsDocument {
+	doc := StatsDocument{
+		SchemaVersion:      SchemaVersion,
+		Since:              st.Since,
+		Until:              st.Until,
+		Days:               st.Days,
+		Prompts:            st.Prompts,
+		PromptsPerDay:      round2(st.PromptsPerDay),
+		PromptsPerWeek:     round2(st.PromptsPerWeek),
+		ActiveDays:         st.ActiveDays,
+		LongestStreak:      Streak{Days: st.LongestStreak.Days},
+		CurrentStreak:      st.CurrentStreak,
+		PromptsByHour:      st.Hours[:],
+		TopHours:           append([]int{}, st.TopHours...),
+		Sessions:           st.Sessions,
+		AvgSessionMinutes:  round2(st.AvgSessionMinutes),
+		AvgSessionMessages: round2(st.AvgSessionMessages),
+		ProjectsPerWeek:    []WeekCount{},
+		AvgProjectsPerWeek: round2(st.AvgProjectsPerWeek),
+		ContextSwitches:    st.ContextSwitches,
+		SwitchRate:         round2(st.SwitchRate),
+		Sleeping:           st.Sleeping,
+		SleepingOpen:       st.SleepingOpen,
+	}
+	if st.LongestStreak.Days > 0 {
+		doc.LongestStreak.Start = &st.LongestStreak.Start
+		doc.LongestStreak.End = &st.LongestStreak.End
+	}
+	for _, w := range st.ProjectsPerWeek {
+		doc.ProjectsPerWeek = append(doc.ProjectsPerWeek, WeekCount{Start: w.Start, Projects: w.Count})
+	}
+	if st.Sleeping > 0 {
+		doc.SleepingOpenShare = round2(float64(st.SleepingOpen) / float64(st.Sleeping))
+	}
+	return doc
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+// newProjects converts a category. Required categories are rendered as []
+// rather than null when empty; optional ones stay nil so they are omitted.
+func newProjects(projects []claude.ProjectInfo, required bool) []Project {
+	if projects == nil && !required {
+		return nil
+	}
+	out := make([]Project, len(projects))
+	for i, p := range projects {
+		out[i] = newProject(p)
+	}
+	return out
+}
+
+func newProject(p claude.ProjectInfo) Project {
+	out := Project{
+		Path:             p.Path,
+		ShortName:        p.ShortName,
+		Aliases:          p.Aliases,
+		Host:             p.Host,
+		Profiles:         p.Profiles,
+		Tags:             p.Tags,
+		Group:            p.Group,
+		ReminderDue:      p.ReminderDue,
+		AckedBy:          p.AckedBy,
+		IgnoredBy:        p.IgnoredBy,
+		WokenBy:          p.WokenBy,
+		PromptCount:      p.PromptCount,
+		LastActivity:     p.LastActivity,
+		FirstActivity:    p.FirstActivity,
+		LastPrompt:       p.LastPrompt,
+		SessionCount:     len(p.Sessions),
+		LatestSummary:    p.LatestSummary,
+		LatestBranch:     p.LatestBranch,
+		GitDirty:         p.GitDirty,
+		GitBranch:        p.GitBranch,
+		UncommittedFiles: p.UncommittedFiles,
+		DaysSinceActive:  p.DaysSinceActive,
+		IsOpenWork:       p.IsOpenWork,
+		Score:            p.Score,
+	}
+	for _, n := range p.Notes {
+		out.Notes = append(out.Notes, Note{Text: n.Text, CreatedAt: n.CreatedAt, RemindAt: n.RemindAt})
+	}
+	for _, t := range p.Todos {
+		out.Todos = append(out.Todos, Todo{Text: t.Text, Source: t.Source, SessionID: t.SessionID, Timestamp: t.Timestamp})
+	}
+	return out
+}
+
+func newSession(s claude.SessionEntry) Session {
+	return Session{
+		ID:           s.SessionID,
+		Summary:      s.Summary,
+		FirstPrompt:  s.FirstPrompt,
+		MessageCount: s.MsgCount,
+		Created:      s.Created,
+		Modified:     s.Modified,
+		GitBranch:    s.GitBranch,
+	}
+}
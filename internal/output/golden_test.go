@@ -0,0 +1,153 @@
+package output
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+// Run "go test ./internal/output -update" after an intended change to the
+// output documents, and bump SchemaVersion if the change is incompatible.
+var update = flag.Bool("update", false, "update golden files and published schemas")
+
+func checkGolden(t *testing.T, path, got string) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if string(want) != got {
+		t.Errorf("%s is out of date; run go test ./internal/output -update and review the diff.\ngot:\n%s", path, got)
+	}
+}
+
+func goldenProject() claude.ProjectInfo {
+	last := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+	remind := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	return claude.ProjectInfo{
+		Path:             "/home/me/src/app",
+		ShortName:        "app",
+		Aliases:          []string{"/Users/me/src/app"},
+		Tags:             []string{"work"},
+		Group:            "clients",
+		Notes:            []claude.Note{{Text: "continue with login", CreatedAt: last, RemindAt: &remind}},
+		PromptCount:      12,
+		LastActivity:     last,
+		FirstActivity:    last.AddDate(0, 0, -5),
+		LastPrompt:       "add tests",
+		Sessions:         []claude.SessionEntry{{SessionID: "s1", Summary: "Login flow", MsgCount: 20, Created: "2026-02-24T09:00:00Z", Modified: "2026-03-01T10:30:00Z", GitBranch: "feature/login"}},
+		LatestSummary:    "Login flow",
+		LatestBranch:     "feature/login",
+		Todos:            []claude.TodoItem{{Text: "handle expired tokens", Source: "TODO", SessionID: "s1", Timestamp: "2026-03-01T10:00:00Z"}},
+		LastMessages:     []string{"internal, must not leak"},
+		GitDirty:         true,
+		GitBranch:        "feature/login",
+		UncommittedFiles: 3,
+		DaysSinceActive:  2,
+		IsOpenWork:       true,
+		Score:            87.5,
+	}
+}
+
+func TestStatusJSONGolden(t *testing.T) {
+	data := analyzer.CategorizedProjects{
+		OpenWork: []claude.ProjectInfo{goldenProject()},
+		Groups:   []string{"clients"},
+	}
+	got, err := RenderJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, filepath.Join("testdata", "status.golden.json"), got+"\n")
+}
+
+func TestProjectJSONGolden(t *testing.T) {
+	got, err := RenderProjectDetailJSON(ProjectDetail{
+		Project:       goldenProject(),
+		RecentPrompts: []claude.HistoryEntry{{Display: "add tests", Timestamp: time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC).UnixMilli(), Project: "/home/me/src/app"}},
+		Acknowledged:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, filepath.Join("testdata", "project.golden.json"), got+"\n")
+}
+
+func TestTimelineJSONGolden(t *testing.T) {
+	p := goldenProject()
+	start := time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC)
+	buckets := []analyzer.TimelineBucket{{
+		Start:   start,
+		End:     start.AddDate(0, 0, 7),
+		Prompts: 12,
+		Projects: []analyzer.TimelineProject{{
+			Project:     p,
+			Prompts:     12,
+			FirstPrompt: p.FirstActivity,
+			LastPrompt:  p.LastActivity,
+			Sessions:    p.Sessions,
+		}},
+	}}
+	got, err := RenderTimelineJSON(buckets, "week")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, filepath.Join("testdata", "timeline.golden.json"), got+"\n")
+}
+
+func TestStatsJSONGolden(t *testing.T) {
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	st := analyzer.Stats{
+		Since:              since,
+		Until:              since.AddDate(0, 0, 14),
+		Days:               14,
+		Prompts:            42,
+		PromptsPerDay:      3,
+		PromptsPerWeek:     21,
+		ActiveDays:         9,
+		LongestStreak:      analyzer.Streak{Days: 4, Start: since.AddDate(0, 0, 2), End: since.AddDate(0, 0, 5)},
+		CurrentStreak:      2,
+		TopHours:           []int{10, 14},
+		Sessions:           6,
+		AvgSessionMinutes:  37.5,
+		AvgSessionMessages: 18.333333,
+		ProjectsPerWeek: []analyzer.WeekCount{
+			{Start: since, Count: 3},
+			{Start: since.AddDate(0, 0, 7), Count: 2},
+		},
+		AvgProjectsPerWeek: 2.5,
+		ContextSwitches:    11,
+		SwitchRate:         0.333333,
+		Sleeping:           4,
+		SleepingOpen:       1,
+	}
+	st.Hours[10] = 20
+	st.Hours[14] = 12
+	st.Hours[21] = 10
+	got, err := RenderStatsJSON(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, filepath.Join("testdata", "stats.golden.json"), got+"\n")
+}
+
+func TestPublishedSchemas(t *testing.T) {
+	for name, doc := range Schemas {
+		got, err := JSONSchema(doc, "squirrel "+name+" output")
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, filepath.Join("..", "..", "schema", name+".schema.json"), got)
+	}
+}
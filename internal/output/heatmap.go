@@ -0,0 +1,132 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/dkd-dobberkau/squirrel/internal/i18n"
+)
+
+var (
+	sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+	sparkASCII  = []rune("_.-=+*#@")
+
+	heatColors = []lipgloss.Color{"#3a3a3a", "#0e4429", "#006d32", "#26a641", "#39d353"}
+	heatASCII  = []string{".", "-", "+", "*", "#"}
+)
+
+// asciiOnly reports whether output can't use colour, in which case charts
+// fall back to plain ASCII characters.
+func asciiOnly() bool {
+	return lipgloss.ColorProfile() == termenv.Ascii
+}
+
+// Sparkline draws values as a row of bars scaled to the largest value.
+func Sparkline(values []int) string {
+	ramp := sparkBlocks
+	if asciiOnly() {
+		ramp = sparkASCII
+	}
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if v > 0 {
+			level = (v*(len(ramp)-1) + max - 1) / max // 1..len-1
+		}
+		b.WriteRune(ramp[level])
+	}
+	return b.String()
+}
+
+// RenderHeatmap draws a GitHub-style contribution grid of daily prompt
+// counts: one column per week up to now, one row per weekday, with month
+// labels on top. The number of weeks shrinks to fit width columns.
+func RenderHeatmap(title string, counts map[string]int, now time.Time, weeks, width int) string {
+	const labelWidth = 4
+	if width > 0 && labelWidth+2*weeks > width {
+		weeks = max((width-labelWidth)/2, 1)
+	}
+	cells := Heatmap(counts, now, weeks)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	// Month labels, placed above the first week starting in that month
+	months := []rune(strings.Repeat(" ", 2*weeks))
+	next := 0
+	for w := 0; w < weeks; w++ {
+		d, _ := time.Parse("2006-01-02", cells[w*7].Date)
+		if w > 0 && d.Day() > 7 {
+			continue
+		}
+		label := []rune(i18n.Month(d))
+		if len(label) > 3 {
+			label = label[:3]
+		}
+		if 2*w < next || 2*w+len(label) > len(months) {
+			continue
+		}
+		copy(months[2*w:], label)
+		next = 2*w + len(label) + 1
+	}
+	b.WriteString(strings.Repeat(" ", labelWidth))
+	b.WriteString(dimStyle.Render(strings.TrimRight(string(months), " ")))
+	b.WriteString("\n")
+
+	ascii := asciiOnly()
+	total, active := 0, 0
+	for day := 0; day < 7; day++ {
+		label := ""
+		if day%2 == 0 && day < 6 {
+			label = i18n.Weekday(time.Date(2026, 3, 2+day, 0, 0, 0, 0, time.UTC)) // 2026-03-02 is a Monday
+		}
+		b.WriteString(dimStyle.Render(label + strings.Repeat(" ", max(labelWidth-lipgloss.Width(label), 0))))
+		for w := 0; w < weeks; w++ {
+			i := w*7 + day
+			if i >= len(cells) {
+				break
+			}
+			c := cells[i]
+			total += c.Count
+			if c.Count > 0 {
+				active++
+			}
+			b.WriteString(heatCell(c.Level, ascii))
+			b.WriteString(" ")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	legend := make([]string, len(heatColors))
+	for level := range heatColors {
+		legend[level] = heatCell(level, ascii)
+	}
+	b.WriteString(strings.Repeat(" ", labelWidth))
+	b.WriteString(dimStyle.Render(i18n.T("heatmap.less")) + " " + strings.Join(legend, " ") + " " + dimStyle.Render(i18n.T("heatmap.more")))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat(" ", labelWidth))
+	b.WriteString(dimStyle.Render(fmt.Sprintf("%s, %s",
+		i18n.N("timeline.prompts", total), i18n.N("heatmap.days", active))))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func heatCell(level int, ascii bool) string {
+	if ascii {
+		return heatASCII[level]
+	}
+	return lipgloss.NewStyle().Foreground(heatColors[level]).Render("■")
+}
@@ -0,0 +1,40 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Tests run without a terminal, so charts use the ASCII fallback.
+
+func TestSparklineASCII(t *testing.T) {
+	if got := Sparkline([]int{0, 1, 7, 0}); got != "_.@_" {
+		t.Errorf("Sparkline = %q", got)
+	}
+	if got := Sparkline([]int{0, 0}); got != "__" {
+		t.Errorf("Sparkline(no activity) = %q", got)
+	}
+}
+
+func TestRenderHeatmapFitsWidth(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.Local) // Wednesday
+	counts := map[string]int{"2026-03-02": 4, "2026-03-03": 1}
+
+	got := RenderHeatmap("Activity", counts, now, 52, 30)
+	lines := strings.Split(got, "\n")
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w > 30 {
+			t.Errorf("line %q is %d wide, want at most 30", line, w)
+		}
+	}
+	if !strings.Contains(got, "Mon ") || !strings.Contains(got, "5 prompts, 2 active days") {
+		t.Errorf("heatmap missing labels or summary:\n%s", got)
+	}
+	// The current week has Monday at level 4 and Tuesday at level 1
+	if !strings.Contains(got, "# \n") || !strings.Contains(got, "- \n") {
+		t.Errorf("heatmap cells not rendered at the end of their rows:\n%s", got)
+	}
+}
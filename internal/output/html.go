@@ -0,0 +1,135 @@
+package output
+
+import (
+	"embed"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/internal/i18n"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+// heatmapWeeks is the number of weeks shown in each project's heatmap.
+const heatmapWeeks = 12
+
+var reportTemplate = template.Must(template.New("report.html.tmpl").Funcs(template.FuncMap{
+	// date shortens an RFC 3339 session timestamp to its date.
+	"date": func(s string) string {
+		if len(s) > 10 {
+			return s[:10]
+		}
+		return s
+	},
+	"t":        i18n.T,
+	"n":        i18n.N,
+	"fullDate": i18n.Date,
+	"dateTime": i18n.DateTime,
+}).ParseFS(templateFiles, "templates/report.html.tmpl"))
+
+// HeatCell is one day in a project's activity heatmap.
+type HeatCell struct {
+	Date  string
+	Count int
+	// Level ranks Count from 0 (no prompts) to 4 relative to the busiest day.
+	Level int
+}
+
+type reportProject struct {
+	claude.ProjectInfo
+	Branch     string
+	Heatmap    []HeatCell
+	ActiveDays int
+}
+
+type reportSection struct {
+	Title    string
+	Projects []reportProject
+}
+
+type report struct {
+	Lang        string
+	GeneratedAt time.Time
+	Weeks       int
+	Sections    []reportSection
+	CSS         template.CSS
+	JS          template.JS
+}
+
+// RenderHTML renders the categorized projects as a self-contained HTML page
+// with sortable tables, per-project activity heatmaps built from the history
+// entries, and expandable sessions and TODOs.
+func RenderHTML(data analyzer.CategorizedProjects, entries []claude.HistoryEntry, now time.Time) (string, error) {
+	css, err := templateFiles.ReadFile("templates/report.css")
+	if err != nil {
+		return "", err
+	}
+	js, err := templateFiles.ReadFile("templates/report.js")
+	if err != nil {
+		return "", err
+	}
+
+	r := report{
+		Lang:        i18n.Current().Tag,
+		GeneratedAt: now,
+		Weeks:       heatmapWeeks,
+		CSS:         template.CSS(css),
+		JS:          template.JS(js),
+	}
+	for _, s := range Sections(data) {
+		section := reportSection{Title: s.Title}
+		for _, p := range s.Projects {
+			cells := Heatmap(claude.DailyActivity(entries, p.Paths()), now, heatmapWeeks)
+			active := 0
+			for _, c := range cells {
+				if c.Count > 0 {
+					active++
+				}
+			}
+			section.Projects = append(section.Projects, reportProject{
+				ProjectInfo: p,
+				Branch:      projectBranch(p),
+				Heatmap:     cells,
+				ActiveDays:  active,
+			})
+		}
+		r.Sections = append(r.Sections, section)
+	}
+
+	var b strings.Builder
+	if err := reportTemplate.Execute(&b, r); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Heatmap lays out daily prompt counts for the given number of weeks up to
+// now, starting on a Monday so that every column of seven cells is one week.
+func Heatmap(counts map[string]int, now time.Time, weeks int) []HeatCell {
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	offset := (int(end.Weekday()) + 6) % 7 // days since Monday
+	start := end.AddDate(0, 0, -offset-7*(weeks-1))
+
+	max := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if c := counts[d.Format("2006-01-02")]; c > max {
+			max = c
+		}
+	}
+
+	var cells []HeatCell
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		c := counts[key]
+		level := 0
+		if c > 0 {
+			level = (4*c + max - 1) / max // ceil(4*c/max), 1..4
+		}
+		cells = append(cells, HeatCell{Date: key, Count: c, Level: level})
+	}
+	return cells
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+func TestHeatmap(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, time.Local) // a Wednesday
+	counts := map[string]int{"2026-03-04": 8, "2026-03-02": 2, "2026-02-01": 5}
+
+	cells := Heatmap(counts, now, 2)
+
+	if len(cells) != 10 {
+		t.Fatalf("expected 7 days of last week + 3 days of this week, got %d", len(cells))
+	}
+	if cells[0].Date != "2026-02-23" {
+		t.Errorf("heatmap should start on a Monday, got %s", cells[0].Date)
+	}
+	last := cells[len(cells)-1]
+	if last.Date != "2026-03-04" || last.Level != 4 {
+		t.Errorf("busiest day = %+v, want level 4 on 2026-03-04", last)
+	}
+	if cells[7].Level != 1 {
+		t.Errorf("2 of 8 prompts should be level 1, got %+v", cells[7])
+	}
+	if cells[1].Level != 0 {
+		t.Errorf("empty day should be level 0, got %+v", cells[1])
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	now := time.Now()
+	data := analyzer.CategorizedProjects{
+		OpenWork: []claude.ProjectInfo{{
+			ShortName:    "<app>",
+			Path:         "/src/app",
+			PromptCount:  3,
+			LastActivity: now,
+			Sessions:     []claude.SessionEntry{{Summary: "Login flow", Modified: "2026-03-01T10:00:00Z"}},
+			Todos:        []claude.TodoItem{{Text: "add tests", Source: "TODO"}},
+		}},
+	}
+	entries := []claude.HistoryEntry{{Project: "/src/app", Timestamp: now.UnixMilli()}}
+
+	got, err := RenderHTML(data, entries, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"<style>",
+		"<script>",
+		"Open work (1)",
+		"&lt;app&gt;",
+		`class="l4"`,
+		"1 session",
+		"add tests",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Contains(got, "<app>") {
+		t.Error("project names must be escaped")
+	}
+	if strings.Contains(got, "http://") || strings.Contains(got, "https://") {
+		t.Error("report must not reference external resources")
+	}
+}
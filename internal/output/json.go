@@ -7,9 +7,9 @@ import (
 	"github.com/dkd-dobberkau/squirrel/internal/claude"
 )
 
-// RenderJSON returns the categorized projects as a JSON string.
+// RenderJSON returns the categorized projects as a StatusDocument JSON string.
 func RenderJSON(data analyzer.CategorizedProjects) (string, error) {
-	b, err := json.MarshalIndent(data, "", "  ")
+	b, err := json.MarshalIndent(NewStatusDocument(data), "", "  ")
 	if err != nil {
 		return "", err
 	}
@@ -18,13 +18,15 @@ func RenderJSON(data analyzer.CategorizedProjects) (string, error) {
 
 // ProjectDetail holds a single project with its recent prompts for detail output.
 type ProjectDetail struct {
-	Project       claude.ProjectInfo   `json:"project"`
+	Project       claude.ProjectInfo    `json:"project"`
 	RecentPrompts []claude.HistoryEntry `json:"recentPrompts"`
+	// Acknowledged is set when an ack or ack rule covers the project.
+	Acknowledged bool `json:"acknowledged"`
 }
 
-// RenderProjectDetailJSON returns the project detail as a JSON string.
+// RenderProjectDetailJSON returns the project detail as a ProjectDocument JSON string.
 func RenderProjectDetailJSON(detail ProjectDetail) (string, error) {
-	b, err := json.MarshalIndent(detail, "", "  ")
+	b, err := json.MarshalIndent(NewProjectDocument(detail), "", "  ")
 	if err != nil {
 		return "", err
 	}
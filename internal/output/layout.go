@@ -0,0 +1,116 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+// ColorModes lists the values accepted by SetColor.
+var ColorModes = []string{"auto", "always", "never"}
+
+// width is the number of terminal cells terminal output is laid out for.
+// 0 means unlimited, e.g. when output goes to a pipe.
+var width int
+
+// SetWidth sets the width terminal output is laid out for; 0 disables
+// fitting lines to the terminal.
+func SetWidth(w int) {
+	width = max(w, 0)
+}
+
+// SetColor selects whether terminal output is coloured. "auto" follows the
+// terminal, NO_COLOR and CLICOLOR_FORCE; "always" colours even when output
+// is not a terminal; "never" writes plain text.
+func SetColor(mode string) error {
+	switch mode {
+	case "auto":
+		lipgloss.SetColorProfile(termenv.NewOutput(os.Stdout).EnvColorProfile())
+	case "always":
+		profile := termenv.ANSI256
+		if ct := strings.ToLower(os.Getenv("COLORTERM")); ct == "truecolor" || ct == "24bit" {
+			profile = termenv.TrueColor
+		}
+		lipgloss.SetColorProfile(profile)
+	case "never":
+		lipgloss.SetColorProfile(termenv.Ascii)
+	default:
+		return fmt.Errorf("invalid color mode %q (use %s)", mode, strings.Join(ColorModes, ", "))
+	}
+	return nil
+}
+
+// lineIndent is the room taken by a project line's marker and group
+// indentation; continuation lines are indented by as much.
+const lineIndent = 6
+
+// nameWidth is the width of the project name column.
+func nameWidth() int {
+	switch {
+	case width == 0:
+		return 22
+	case width >= 120:
+		return 32
+	}
+	// Leave room for the date, prompt count and sparkline
+	return min(max(width-52, 10), 22)
+}
+
+// joinColumns joins the columns of a project line with " | ". When the
+// width is known, columns that don't fit on the line are wrapped onto
+// indented continuation lines and columns wider than a line are truncated.
+func joinColumns(cols []string) string {
+	if width == 0 {
+		return strings.Join(cols, " | ")
+	}
+	room := max(width-lineIndent, 20)
+
+	var lines []string
+	cur, curWidth := "", 0
+	for _, c := range cols {
+		c = truncate(c, room)
+		w := ansi.StringWidth(c)
+		switch {
+		case curWidth == 0:
+			cur, curWidth = c, w
+		case curWidth+3+w <= room:
+			cur += " | " + c
+			curWidth += 3 + w
+		default:
+			lines = append(lines, cur)
+			cur, curWidth = strings.Repeat(" ", lineIndent)+c, w
+		}
+	}
+	return strings.Join(append(lines, cur), "\n")
+}
+
+// fitText truncates s to the room left on a line after used cells, or to
+// def cells when the width is unknown.
+func fitText(s string, used, def int) string {
+	if width == 0 {
+		return truncate(s, def)
+	}
+	return truncate(s, max(width-used, 10))
+}
+
+// truncate shortens s to at most n terminal cells, marking the cut with
+// "~". It never splits a multi-byte character, wide characters count as
+// two cells and ANSI styling is preserved.
+func truncate(s string, n int) string {
+	if ansi.StringWidth(s) <= n {
+		return s
+	}
+	if n < 1 {
+		return ""
+	}
+	return ansi.Truncate(s, n, "~")
+}
+
+// pad fills s with spaces up to n terminal cells.
+func pad(s string, n int) string {
+	return s + strings.Repeat(" ", max(n-ansi.StringWidth(s), 0))
+}
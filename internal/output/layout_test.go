@@ -0,0 +1,77 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestTruncateCells(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"squirrel", 10, "squirrel"},
+		{"squirrel", 5, "squi~"},
+		{"Übergrößenträger", 6, "Überg~"},
+		{"日本語プロジェクト", 7, "日本語~"},
+		{"🐿️ nuts", 3, "🐿️~"},
+		{"abc", 0, ""},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.in, tt.n)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+		if w := ansi.StringWidth(got); w > tt.n {
+			t.Errorf("truncate(%q, %d) is %d cells wide", tt.in, tt.n, w)
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	if got := pad("日本", 6); got != "日本  " {
+		t.Errorf("pad = %q", got)
+	}
+}
+
+func TestJoinColumnsWraps(t *testing.T) {
+	t.Cleanup(func() { SetWidth(0) })
+
+	cols := []string{"name", "date", "feature/a-very-long-branch-name-that-goes-on"}
+	if got := joinColumns(cols); got != strings.Join(cols, " | ") {
+		t.Errorf("unlimited width: %q", got)
+	}
+
+	SetWidth(40)
+	got := joinColumns(cols)
+	lines := strings.Split(got, "\n")
+	if len(lines) != 2 || lines[0] != "name | date" {
+		t.Fatalf("joinColumns at width 40 =\n%s", got)
+	}
+	if !strings.HasPrefix(lines[1], strings.Repeat(" ", lineIndent)+"feature/") || ansi.StringWidth(lines[1]) > 40 {
+		t.Errorf("continuation line %q not indented or too wide", lines[1])
+	}
+}
+
+func TestSetColorNever(t *testing.T) {
+	t.Cleanup(func() { SetColor("auto") })
+
+	if err := SetColor("never"); err != nil {
+		t.Fatal(err)
+	}
+	if got := warnStyle.Render("x"); got != "x" {
+		t.Errorf("styled output with --color=never: %q", got)
+	}
+	if err := SetColor("always"); err != nil {
+		t.Fatal(err)
+	}
+	if got := warnStyle.Render("x"); got == "x" {
+		t.Error("no styling with --color=always")
+	}
+	if err := SetColor("sometimes"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
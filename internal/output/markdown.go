@@ -0,0 +1,225 @@
+package output
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/internal/i18n"
+)
+
+// RenderMarkdown renders the categorized projects as GitHub-flavoured
+// Markdown: one table per section, followed by collapsible session lists
+// and TODO checklists.
+func RenderMarkdown(data analyzer.CategorizedProjects) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n", i18n.T("title.status"))
+
+	sections := Sections(data)
+	for _, s := range sections {
+		fmt.Fprintf(&b, "\n## %s (%d)\n", s.Title, len(s.Projects))
+		writeMarkdownSection(&b, s.Projects, data.Groups)
+	}
+
+	if len(sections) == 0 {
+		fmt.Fprintf(&b, "\n_%s_\n", i18n.T("empty"))
+	}
+
+	return b.String()
+}
+
+// writeMarkdownSection writes a table per group (or one table without
+// groups) and the details of the listed projects.
+func writeMarkdownSection(b *strings.Builder, projects []claude.ProjectInfo, groups []string) {
+	if len(groups) == 0 {
+		writeMarkdownTable(b, projects)
+		writeMarkdownDetails(b, projects)
+		return
+	}
+
+	for _, g := range append(slices.Clone(groups), "") {
+		var members []claude.ProjectInfo
+		for _, p := range projects {
+			if p.Group == g {
+				members = append(members, p)
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		header := g
+		if header == "" {
+			header = i18n.T("group.none")
+		}
+		fmt.Fprintf(b, "\n### %s\n", mdEscape(header))
+		writeMarkdownTable(b, members)
+		writeMarkdownDetails(b, members)
+	}
+}
+
+func writeMarkdownTable(b *strings.Builder, projects []claude.ProjectInfo) {
+	fmt.Fprintf(b, "\n| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+		i18n.T("label.project"), i18n.T("label.path"), i18n.T("label.lastActivity"), i18n.T("label.prompts"),
+		i18n.T("label.branch"), i18n.T("label.git"), i18n.T("label.tags"), i18n.T("label.note"))
+	b.WriteString("|---|---|---|--:|---|---|---|---|\n")
+	for _, p := range projects {
+		git := ""
+		switch {
+		case p.UncommittedFiles > 0:
+			git = i18n.T("project.uncommitted", p.UncommittedFiles)
+		case p.GitBranch != "":
+			git = i18n.T("git.clean")
+		}
+		note := ""
+		if len(p.Notes) > 0 {
+			note = p.Notes[0].Text
+		}
+		fmt.Fprintf(b, "| %s | `%s` | %s | %d | %s | %s | %s | %s |\n",
+			mdEscape(p.ShortName),
+			strings.ReplaceAll(p.Path, "`", "'"),
+			i18n.Date(p.LastActivity),
+			p.PromptCount,
+			mdEscape(projectBranch(p)),
+			git,
+			mdEscape(strings.Join(p.Tags, ", ")),
+			mdEscape(note),
+		)
+	}
+}
+
+// writeMarkdownDetails writes a collapsible block per project that has
+// sessions or TODOs.
+func writeMarkdownDetails(b *strings.Builder, projects []claude.ProjectInfo) {
+	for _, p := range projects {
+		if len(p.Sessions) == 0 && len(p.Todos) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "\n<details>\n<summary>%s</summary>\n\n",
+			i18n.T("md.details", htmlEscape(p.ShortName), len(p.Sessions), len(p.Todos)))
+		writeMarkdownSessions(b, p.Sessions)
+		if len(p.Sessions) > 0 && len(p.Todos) > 0 {
+			b.WriteString("\n")
+		}
+		writeMarkdownTodos(b, p.Todos)
+		b.WriteString("\n</details>\n")
+	}
+}
+
+func writeMarkdownSessions(b *strings.Builder, sessions []claude.SessionEntry) {
+	for _, s := range sessions {
+		summary := s.Summary
+		if summary == "" {
+			summary = s.FirstPrompt
+		}
+		date := s.Modified
+		if len(date) > 10 {
+			date = date[:10]
+		}
+		fmt.Fprintf(b, "- %s %s (%s)\n", date, mdEscape(truncate(summary, 80)), i18n.T("session.msgs", s.MsgCount))
+	}
+}
+
+func writeMarkdownTodos(b *strings.Builder, todos []claude.TodoItem) {
+	for _, todo := range todos {
+		fmt.Fprintf(b, "- [ ] %s _(%s)_\n", mdEscape(todo.Text), todo.Source)
+	}
+}
+
+// RenderProjectDetailMarkdown renders a single project as Markdown.
+func RenderProjectDetailMarkdown(detail ProjectDetail) string {
+	p := detail.Project
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", mdEscape(p.ShortName))
+
+	b.WriteString("| | |\n|---|---|\n")
+	row := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "| %s | %s |\n", i18n.T(key), value)
+		}
+	}
+	row("label.path", "`"+strings.ReplaceAll(p.Path, "`", "'")+"`")
+	row("label.host", mdEscape(p.Host))
+	row("label.profile", mdEscape(strings.Join(p.Profiles, ", ")))
+	for _, alias := range p.Aliases {
+		row("label.alias", "`"+strings.ReplaceAll(alias, "`", "'")+"`")
+	}
+	row("label.group", mdEscape(p.Group))
+	row("label.tags", mdEscape(strings.Join(p.Tags, ", ")))
+	row("label.branch", mdEscape(projectBranch(p)))
+	if p.GitDirty {
+		row("label.gitStatus", i18n.T("project.uncommitted", p.UncommittedFiles))
+	} else if p.GitBranch != "" {
+		row("label.gitStatus", i18n.T("git.clean"))
+	}
+	row("label.score", fmt.Sprintf("%.1f", p.Score))
+	row("label.prompts", fmt.Sprintf("%d", p.PromptCount))
+	row("label.firstActivity", i18n.DateTime(p.FirstActivity))
+	row("label.lastActivity", i18n.DateTime(p.LastActivity))
+	if p.DaysSinceActive > 0 {
+		row("label.inactive", i18n.N("detail.days", p.DaysSinceActive))
+	}
+
+	if len(p.Notes) > 0 {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", i18n.T("section.notes"), len(p.Notes))
+		for _, n := range p.Notes {
+			line := fmt.Sprintf("- %s %s", i18n.DateTime(n.CreatedAt), mdEscape(n.Text))
+			if n.RemindAt != nil {
+				line += fmt.Sprintf(" _%s_", i18n.T("note.reminder", i18n.Date(*n.RemindAt)))
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	if len(p.Sessions) > 0 {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n<details>\n<summary>%s</summary>\n\n",
+			i18n.T("section.sessions"), len(p.Sessions), i18n.T("md.showSessions", len(p.Sessions)))
+		writeMarkdownSessions(&b, p.Sessions)
+		b.WriteString("\n</details>\n")
+	}
+
+	if len(detail.RecentPrompts) > 0 {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", i18n.T("section.prompts"), len(detail.RecentPrompts))
+		for _, pr := range detail.RecentPrompts {
+			ts := i18n.ShortDateTime(time.UnixMilli(pr.Timestamp))
+			fmt.Fprintf(&b, "- %s %s\n", ts, mdEscape(truncate(pr.Display, 120)))
+		}
+	}
+
+	if len(p.Todos) > 0 {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", i18n.T("section.todos"), len(p.Todos))
+		writeMarkdownTodos(&b, p.Todos)
+	}
+
+	return b.String()
+}
+
+// projectBranch returns the git branch, falling back to the latest session's.
+func projectBranch(p claude.ProjectInfo) string {
+	if p.GitBranch != "" {
+		return p.GitBranch
+	}
+	return p.LatestBranch
+}
+
+var mdReplacer = strings.NewReplacer(
+	"\r\n", " ", "\n", " ", "\r", " ",
+	"|", `\|`, "<", "&lt;", ">", "&gt;",
+	"*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`,
+)
+
+// mdEscape makes text safe for a single Markdown line or table cell.
+func mdEscape(s string) string {
+	return mdReplacer.Replace(s)
+}
+
+var htmlReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\n", " ")
+
+// htmlEscape escapes text placed inside raw HTML such as <summary>.
+func htmlEscape(s string) string {
+	return htmlReplacer.Replace(s)
+}
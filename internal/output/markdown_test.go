@@ -0,0 +1,86 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	data := analyzer.CategorizedProjects{
+		OpenWork: []claude.ProjectInfo{{
+			ShortName:        "a|b",
+			Path:             "/src/a",
+			PromptCount:      12,
+			LastActivity:     time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local),
+			UncommittedFiles: 3,
+			GitBranch:        "feature",
+			Sessions:         []claude.SessionEntry{{Summary: "Refactor parser", Modified: "2026-03-01T10:00:00Z", MsgCount: 7}},
+			Todos:            []claude.TodoItem{{Text: "write tests", Source: "TODO"}},
+		}},
+	}
+
+	got := RenderMarkdown(data)
+
+	for _, want := range []string{
+		"## Open work (1)",
+		"| Project | Path |",
+		`| a\|b | ` + "`/src/a`" + ` | Mar 1, 2026 | 12 | feature | 3 uncommitted |`,
+		"<details>\n<summary>a|b: 1 sessions, 1 TODOs</summary>",
+		"- 2026-03-01 Refactor parser (7 msgs)",
+		"- [ ] write tests _(TODO)_",
+		"</details>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Recent activity (") {
+		t.Error("empty sections should be omitted")
+	}
+}
+
+func TestRenderMarkdownGroups(t *testing.T) {
+	data := analyzer.CategorizedProjects{
+		RecentActivity: []claude.ProjectInfo{
+			{ShortName: "x", Group: "acme"},
+			{ShortName: "y"},
+		},
+		Groups: []string{"acme"},
+	}
+
+	got := RenderMarkdown(data)
+	acme := strings.Index(got, "### acme")
+	rest := strings.Index(got, "### ungrouped")
+	if acme < 0 || rest < acme {
+		t.Errorf("expected group headers in order:\n%s", got)
+	}
+}
+
+func TestRenderProjectDetailMarkdown(t *testing.T) {
+	got := RenderProjectDetailMarkdown(ProjectDetail{
+		Project: claude.ProjectInfo{
+			ShortName: "app",
+			Path:      "/src/app",
+			Notes:     []claude.Note{{Text: "continue with *login*", CreatedAt: time.Now()}},
+			Todos:     []claude.TodoItem{{Text: "fix bug", Source: "FIXME"}},
+		},
+		RecentPrompts: []claude.HistoryEntry{{Display: "line one\nline two", Timestamp: time.Now().UnixMilli()}},
+	})
+
+	for _, want := range []string{
+		"# app",
+		"| Path | `/src/app` |",
+		`continue with \*login\*`,
+		"line one line two",
+		"## TODOs (1)",
+		"- [ ] fix bug _(FIXME)_",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+// Fields lists the columns of csv, tsv and ndjson output in their stable
+// order. This is a compatibility contract: new columns are only appended,
+// existing ones keep their name, position and meaning.
+var Fields = []string{
+	"category",
+	"path",
+	"name",
+	"score",
+	"prompts",
+	"branch",
+	"dirty",
+	"uncommitted",
+	"lastActivity",
+	"daysSinceActive",
+	"acknowledged",
+	"ackedBy",
+	"group",
+	"tags",
+	"host",
+}
+
+// ParseFields validates a --fields selection. An empty selection means all
+// fields in their default order.
+func ParseFields(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return Fields, nil
+	}
+	for _, n := range names {
+		if !slices.Contains(Fields, n) {
+			return nil, fmt.Errorf("unknown field %q (available: %s)", n, strings.Join(Fields, ", "))
+		}
+	}
+	return names, nil
+}
+
+// fieldValue returns the value of one column for a project.
+func fieldValue(field, category string, p claude.ProjectInfo) any {
+	switch field {
+	case "category":
+		return category
+	case "path":
+		return p.Path
+	case "name":
+		return p.ShortName
+	case "score":
+		return math.Round(p.Score*10) / 10
+	case "prompts":
+		return p.PromptCount
+	case "branch":
+		return projectBranch(p)
+	case "dirty":
+		return p.GitDirty
+	case "uncommitted":
+		return p.UncommittedFiles
+	case "lastActivity":
+		return p.LastActivity.Format(time.RFC3339)
+	case "daysSinceActive":
+		return p.DaysSinceActive
+	case "acknowledged":
+		return category == "acknowledged"
+	case "ackedBy":
+		return p.AckedBy
+	case "group":
+		return p.Group
+	case "tags":
+		return strings.Join(p.Tags, ";")
+	case "host":
+		return p.Host
+	}
+	return nil
+}
+
+type row struct {
+	category string
+	project  claude.ProjectInfo
+}
+
+func statusRows(data analyzer.CategorizedProjects) []row {
+	var rows []row
+	for _, s := range Sections(data) {
+		for _, p := range s.Projects {
+			rows = append(rows, row{s.Name, p})
+		}
+	}
+	return rows
+}
+
+// detailRow returns the row of a project detail, which has no category
+// unless it is acknowledged.
+func detailRow(detail ProjectDetail) row {
+	if detail.Acknowledged {
+		return row{"acknowledged", detail.Project}
+	}
+	return row{"", detail.Project}
+}
+
+// RenderCSV returns one row per project with a header line, separated by
+// comma or, for TSV, by tab.
+func RenderCSV(data analyzer.CategorizedProjects, fields []string, sep rune) (string, error) {
+	return renderDelimited(statusRows(data), fields, sep)
+}
+
+// RenderProjectCSV returns the project as a single delimited row.
+func RenderProjectCSV(detail ProjectDetail, fields []string, sep rune) (string, error) {
+	return renderDelimited([]row{detailRow(detail)}, fields, sep)
+}
+
+func renderDelimited(rows []row, fields []string, sep rune) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = sep
+
+	if err := w.Write(fields); err != nil {
+		return "", err
+	}
+	record := make([]string, len(fields))
+	for _, r := range rows {
+		for i, f := range fields {
+			record[i] = fmt.Sprint(fieldValue(f, r.category, r.project))
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// RenderNDJSON returns one JSON object per line and project, with keys in
+// field order.
+func RenderNDJSON(data analyzer.CategorizedProjects, fields []string) (string, error) {
+	return renderNDJSON(statusRows(data), fields)
+}
+
+// RenderProjectNDJSON returns the project as a single JSON line.
+func RenderProjectNDJSON(detail ProjectDetail, fields []string) (string, error) {
+	return renderNDJSON([]row{detailRow(detail)}, fields)
+}
+
+func renderNDJSON(rows []row, fields []string) (string, error) {
+	var buf bytes.Buffer
+	for _, r := range rows {
+		buf.WriteByte('{')
+		for i, f := range fields {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(f)
+			value, err := json.Marshal(fieldValue(f, r.category, r.project))
+			if err != nil {
+				return "", err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteString("}\n")
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+func rowsTestData() analyzer.CategorizedProjects {
+	last := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	return analyzer.CategorizedProjects{
+		OpenWork: []claude.ProjectInfo{{
+			Path: "/src/a,b", ShortName: "a,b", Score: 42.345, PromptCount: 9,
+			GitBranch: "feature", GitDirty: true, UncommittedFiles: 2, LastActivity: last,
+			Tags: []string{"x", "y"},
+		}},
+		Acknowledged: []claude.ProjectInfo{{Path: "/src/c", ShortName: "c", LastActivity: last, AckedBy: "/src/*"}},
+	}
+}
+
+func TestFieldsContract(t *testing.T) {
+	// The column order is documented as stable; only appending is allowed.
+	want := "category,path,name,score,prompts,branch,dirty,uncommitted,lastActivity,daysSinceActive,acknowledged,ackedBy,group,tags,host"
+	if got := strings.Join(Fields, ","); !strings.HasPrefix(got, want) {
+		t.Errorf("Fields changed incompatibly:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestRenderCSV(t *testing.T) {
+	got, err := RenderCSV(rowsTestData(), Fields, ',')
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got:\n%s", got)
+	}
+	if lines[1] != `openWork,"/src/a,b","a,b",42.3,9,feature,true,2,2026-03-01T10:00:00Z,0,false,,,x;y,` {
+		t.Errorf("row = %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "acknowledged,/src/c,") || !strings.Contains(lines[2], ",true,/src/*,") {
+		t.Errorf("ack row = %s", lines[2])
+	}
+}
+
+func TestRenderProjectCSV(t *testing.T) {
+	data := rowsTestData()
+	for _, tt := range []struct {
+		detail ProjectDetail
+		want   string
+	}{
+		{ProjectDetail{Project: data.OpenWork[0]}, `,"a,b",false` + "\n"},
+		{ProjectDetail{Project: data.Acknowledged[0], Acknowledged: true}, "acknowledged,c,true\n"},
+	} {
+		got, err := RenderProjectCSV(tt.detail, []string{"category", "name", "acknowledged"}, ',')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if row := strings.SplitN(got, "\n", 2)[1]; row != tt.want {
+			t.Errorf("row = %q, want %q", row, tt.want)
+		}
+	}
+}
+
+func TestRenderTSVFields(t *testing.T) {
+	fields, err := ParseFields([]string{"name", "prompts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := RenderCSV(rowsTestData(), fields, '\t')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "name\tprompts\na,b\t9\nc\t0\n" {
+		t.Errorf("tsv = %q", got)
+	}
+
+	if _, err := ParseFields([]string{"nope"}); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestRenderNDJSON(t *testing.T) {
+	got, err := RenderNDJSON(rowsTestData(), []string{"path", "prompts", "dirty"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if lines[0] != `{"path":"/src/a,b","prompts":9,"dirty":true}` {
+		t.Errorf("line = %s", lines[0])
+	}
+	for _, l := range lines {
+		var v map[string]any
+		if err := json.Unmarshal([]byte(l), &v); err != nil {
+			t.Errorf("invalid JSON line %q: %v", l, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package output
+
+import (
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/internal/i18n"
+)
+
+// Section is one non-empty category of CategorizedProjects.
+type Section struct {
+	// Name is the category's JSON key, e.g. "openWork".
+	Name string
+	// Title is the localized heading shown to users.
+	Title string
+	// Marker is the one-character prefix used in terminal output.
+	Marker   string
+	Projects []claude.ProjectInfo
+}
+
+// Sections returns the non-empty categories in display order.
+func Sections(data analyzer.CategorizedProjects) []Section {
+	all := []Section{
+		{"openWork", i18n.T("section.openWork"), "!", data.OpenWork},
+		{"recentActivity", i18n.T("section.recentActivity"), "+", data.RecentActivity},
+		{"sleeping", i18n.T("section.sleeping"), "~", data.Sleeping},
+		{"acknowledged", i18n.T("section.acknowledged"), "✓", data.Acknowledged},
+		{"ignored", i18n.T("section.ignored"), "-", data.Ignored},
+	}
+	var out []Section
+	for _, s := range all {
+		if len(s.Projects) > 0 {
+			out = append(out, s)
+		}
+	}
+	return out
+}
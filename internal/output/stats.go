@@ -0,0 +1,78 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/i18n"
+)
+
+// RenderStats renders statistics as styled terminal output.
+func RenderStats(st analyzer.Stats) string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(i18n.T("stats.title")))
+	b.WriteString("\n")
+	// Until is exclusive, so the last day shown is the one it ends
+	last := st.Until.Add(-time.Nanosecond)
+	b.WriteString(dimStyle.Render(i18n.T("stats.range", i18n.Date(st.Since), i18n.Date(last), st.Days)))
+	b.WriteString("\n\n")
+
+	b.WriteString(sectionStyle.Render(i18n.T("section.activity")))
+	b.WriteString("\n")
+	writeStat(&b, "stats.prompts", i18n.T("stats.promptsValue", st.Prompts, st.PromptsPerDay, st.PromptsPerWeek))
+	writeStat(&b, "stats.activeDays", i18n.T("stats.activeDaysValue", st.ActiveDays, st.Days))
+	streak := i18n.N("detail.days", st.LongestStreak.Days)
+	if st.LongestStreak.Days > 0 {
+		streak += dimStyle.Render(fmt.Sprintf(" (%s - %s)", i18n.ShortDate(st.LongestStreak.Start), i18n.ShortDate(st.LongestStreak.End)))
+	}
+	writeStat(&b, "stats.longestStreak", streak)
+	writeStat(&b, "stats.currentStreak", i18n.N("detail.days", st.CurrentStreak))
+	var hours []string
+	for _, h := range st.TopHours {
+		hours = append(hours, fmt.Sprintf("%02d:00 (%d)", h, st.Hours[h]))
+	}
+	writeStat(&b, "stats.topHours", strings.Join(hours, ", "))
+	writeStat(&b, "stats.byHour", okStyle.Render(Sparkline(st.Hours[:]))+dimStyle.Render(" 0-23h"))
+
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render(i18n.T("section.sessions")))
+	b.WriteString("\n")
+	writeStat(&b, "stats.sessions", fmt.Sprint(st.Sessions))
+	writeStat(&b, "stats.avgLength", i18n.T("stats.minutes", st.AvgSessionMinutes))
+	writeStat(&b, "stats.avgMessages", fmt.Sprintf("%.1f", st.AvgSessionMessages))
+
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render(i18n.T("stats.focus")))
+	b.WriteString("\n")
+	weekly := make([]int, len(st.ProjectsPerWeek))
+	for i, w := range st.ProjectsPerWeek {
+		weekly[i] = w.Count
+	}
+	writeStat(&b, "stats.projectsPerWeek", fmt.Sprintf("%.1f  %s", st.AvgProjectsPerWeek, okStyle.Render(Sparkline(weekly))))
+	writeStat(&b, "stats.contextSwitches", i18n.T("stats.switchesValue", st.ContextSwitches, st.SwitchRate*100))
+	share := 0.0
+	if st.Sleeping > 0 {
+		share = float64(st.SleepingOpen) / float64(st.Sleeping) * 100
+	}
+	writeStat(&b, "stats.sleepingOpen", i18n.T("stats.sleepingOpenValue", st.SleepingOpen, st.Sleeping, share))
+
+	return b.String()
+}
+
+// RenderStatsJSON returns statistics as a StatsDocument JSON string.
+func RenderStatsJSON(st analyzer.Stats) (string, error) {
+	b, err := json.MarshalIndent(NewStatsDocument(st), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// writeStat writes one aligned "label: value" line of the statistics.
+func writeStat(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "  %s%s\n", pad(i18n.T(key)+":", 24), value)
+}
@@ -0,0 +1,129 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/i18n"
+)
+
+// BuiltinTemplates lists the names of the templates shipped with squirrel.
+var BuiltinTemplates = []string{"oneline", "compact", "porcelain"}
+
+// Template is a user-supplied or built-in text/template. It is executed
+// against CategorizedProjects for project lists and against ProjectDetail
+// for the detail view. Templates defining "status" and "project" blocks
+// use them for the respective view; otherwise the whole file is executed.
+type Template struct {
+	tmpl *template.Template
+}
+
+// LoadTemplate parses the template file at path.
+func LoadTemplate(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading template: %w", err)
+	}
+	t, err := template.New(filepath.Base(path)).Funcs(TemplateFuncs(time.Time{})).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	return &Template{tmpl: t}, nil
+}
+
+// BuiltinTemplate returns the built-in template with the given name.
+func BuiltinTemplate(name string) (*Template, error) {
+	if !slices.Contains(BuiltinTemplates, name) {
+		return nil, fmt.Errorf("unknown template %q", name)
+	}
+	t, err := template.New(name+".tmpl").Funcs(TemplateFuncs(time.Time{})).ParseFS(templateFiles, "templates/"+name+".tmpl")
+	if err != nil {
+		return nil, err
+	}
+	return &Template{tmpl: t}, nil
+}
+
+// RenderStatus executes the template against a project list. Relative times
+// count from now, normally the end of the analysed range.
+func (t *Template) RenderStatus(data analyzer.CategorizedProjects, now time.Time) (string, error) {
+	return t.execute("status", data, now)
+}
+
+// RenderProject executes the template against a project detail. Relative
+// times count from now, normally the end of the analysed range.
+func (t *Template) RenderProject(detail ProjectDetail, now time.Time) (string, error) {
+	return t.execute("project", detail, now)
+}
+
+func (t *Template) execute(block string, data any, now time.Time) (string, error) {
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+	tmpl.Funcs(template.FuncMap{"relTime": relTime(now)})
+	if named := tmpl.Lookup(block); named != nil {
+		tmpl = named
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("executing template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// TemplateFuncs returns the helper functions available in templates:
+//
+//	sections   non-empty categories of a project list (see Section)
+//	relTime    time relative to now in the current language, e.g. "3 days ago"
+//	date       a time.Time or Unix milliseconds as a localized date and time
+//	t, n       a catalogue message (see i18n.T and i18n.N)
+//	truncate   shorten a string to n characters
+//	branch     a project's git branch, falling back to the latest session's
+//	join       strings.Join
+//	title, section, warn, ok, sleep, dim, group
+//	           the terminal output styles
+func TemplateFuncs(now time.Time) template.FuncMap {
+	return template.FuncMap{
+		"sections": Sections,
+		"relTime":  relTime(now),
+		"date":     formatDate,
+		"t":        i18n.T,
+		"n":        i18n.N,
+		"truncate": func(s string, n int) string { return truncate(s, n) },
+		"branch":   projectBranch,
+		"join":     strings.Join,
+		"title":    renderWith(titleStyle.UnsetMarginBottom()),
+		"section":  renderWith(sectionStyle.UnsetMarginTop()),
+		"warn":     renderWith(warnStyle),
+		"ok":       renderWith(okStyle),
+		"sleep":    renderWith(sleepStyle),
+		"dim":      renderWith(dimStyle),
+		"group":    renderWith(groupStyle),
+	}
+}
+
+func relTime(now time.Time) func(time.Time) string {
+	return func(t time.Time) string { return i18n.RelTime(t, now) }
+}
+
+func renderWith(style interface{ Render(...string) string }) func(string) string {
+	return func(s string) string { return style.Render(s) }
+}
+
+// formatDate accepts a time.Time or Unix milliseconds as found in
+// HistoryEntry.Timestamp.
+func formatDate(v any) (string, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return i18n.DateTime(v), nil
+	case int64:
+		return i18n.DateTime(time.UnixMilli(v)), nil
+	}
+	return "", fmt.Errorf("date: unsupported value %T", v)
+}
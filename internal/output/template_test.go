@@ -0,0 +1,103 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+func TestBuiltinTemplatePorcelain(t *testing.T) {
+	tmpl, err := BuiltinTemplate("porcelain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := time.Unix(1767225600, 0)
+	data := analyzer.CategorizedProjects{
+		OpenWork: []claude.ProjectInfo{{Path: "/src/my app", PromptCount: 7, UncommittedFiles: 2, LastActivity: last}},
+		Sleeping: []claude.ProjectInfo{{Path: "/src/old", PromptCount: 1, LastActivity: last}},
+	}
+
+	got, err := tmpl.RenderStatus(data, last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "openWork 7 2 1767225600 /src/my app\nsleeping 1 0 1767225600 /src/old\n"
+	if got != want {
+		t.Errorf("porcelain =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestBuiltinTemplatesRender(t *testing.T) {
+	data := analyzer.CategorizedProjects{
+		OpenWork: []claude.ProjectInfo{{ShortName: "app", Path: "/src/app", LastActivity: time.Now()}},
+	}
+	detail := ProjectDetail{
+		Project:       data.OpenWork[0],
+		RecentPrompts: []claude.HistoryEntry{{Display: "hi", Timestamp: time.Now().UnixMilli()}},
+	}
+	for _, name := range BuiltinTemplates {
+		tmpl, err := BuiltinTemplate(name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if out, err := tmpl.RenderStatus(data, time.Now()); err != nil || out == "" {
+			t.Errorf("%s status: %q, %v", name, out, err)
+		}
+		if out, err := tmpl.RenderProject(detail, time.Now()); err != nil || out == "" {
+			t.Errorf("%s project: %q, %v", name, out, err)
+		}
+	}
+
+	if _, err := BuiltinTemplate("report.html"); err == nil {
+		t.Error("expected error for unknown template")
+	}
+}
+
+func TestTemplateRelTime(t *testing.T) {
+	tmpl, err := BuiltinTemplate("oneline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	data := analyzer.CategorizedProjects{
+		Sleeping: []claude.ProjectInfo{{ShortName: "old", LastActivity: last}},
+	}
+
+	// Relative times count from the given time, not the wall clock
+	got, err := tmpl.RenderStatus(data, last.AddDate(0, 0, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "old (3 days ago)") {
+		t.Errorf("oneline = %q, want old 3 days ago", got)
+	}
+}
+
+func TestLoadTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "names.tmpl")
+	os.WriteFile(path, []byte(`{{range sections .}}{{.Name}}:{{range .Projects}} {{truncate .ShortName 4}}{{end}};{{end}}`), 0644)
+
+	tmpl, err := LoadTemplate(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := tmpl.RenderStatus(analyzer.CategorizedProjects{
+		RecentActivity: []claude.ProjectInfo{{ShortName: "squirrel"}, {ShortName: "app"}},
+	}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "recentActivity: squ~ app;" {
+		t.Errorf("got %q", got)
+	}
+
+	os.WriteFile(path, []byte(`{{.Nope`), 0644)
+	if _, err := LoadTemplate(path); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,21 @@
+// Sort a table by the clicked column; cells provide data-sort values.
+document.querySelectorAll("table.projects th").forEach(function (th, col) {
+  th.addEventListener("click", function () {
+    var table = th.closest("table");
+    var tbody = table.tBodies[0];
+    var asc = !th.classList.contains("sorted-asc");
+    table.querySelectorAll("th").forEach(function (h) {
+      h.classList.remove("sorted-asc", "sorted-desc");
+    });
+    th.classList.add(asc ? "sorted-asc" : "sorted-desc");
+
+    var rows = Array.prototype.slice.call(tbody.rows);
+    rows.sort(function (a, b) {
+      var x = a.cells[col].dataset.sort, y = b.cells[col].dataset.sort;
+      var nx = parseFloat(x), ny = parseFloat(y);
+      var cmp = !isNaN(nx) && !isNaN(ny) ? nx - ny : x.localeCompare(y);
+      return asc ? cmp : -cmp;
+    });
+    rows.forEach(function (r) { tbody.appendChild(r); });
+  });
+});
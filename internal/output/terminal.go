@@ -2,12 +2,14 @@ package output
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
 	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/internal/i18n"
 )
 
 var (
@@ -32,57 +34,61 @@ var (
 
 	dimStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#666666"))
+
+	groupStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#DDA0DD"))
 )
 
 // RenderTerminal prints the categorized projects as styled terminal output.
 func RenderTerminal(data analyzer.CategorizedProjects) string {
 	var b strings.Builder
 
-	b.WriteString(titleStyle.Render("Squirrel - Deine vergessenen Nuesse"))
+	b.WriteString(titleStyle.Render(i18n.T("title.status")))
 	b.WriteString("\n\n")
 
 	if len(data.OpenWork) > 0 {
-		b.WriteString(sectionStyle.Render(fmt.Sprintf("Offene Baustellen (%d)", len(data.OpenWork))))
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("%s (%d)", i18n.T("section.openWork"), len(data.OpenWork))))
 		b.WriteString("\n")
-		for _, p := range data.OpenWork {
-			b.WriteString(warnStyle.Render("  ! "))
-			b.WriteString(formatProject(p))
-			b.WriteString("\n")
-		}
+		writeProjects(&b, data.OpenWork, data.Groups, func(p claude.ProjectInfo) string {
+			return warnStyle.Render("  ! ") + formatProject(p)
+		})
 	}
 
 	if len(data.RecentActivity) > 0 {
-		b.WriteString(sectionStyle.Render(fmt.Sprintf("\nLetzte Aktivitaet (%d)", len(data.RecentActivity))))
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("\n%s (%d)", i18n.T("section.recentActivity"), len(data.RecentActivity))))
 		b.WriteString("\n")
-		for _, p := range data.RecentActivity {
-			b.WriteString(okStyle.Render("  + "))
-			b.WriteString(formatProject(p))
-			b.WriteString("\n")
-		}
+		writeProjects(&b, data.RecentActivity, data.Groups, func(p claude.ProjectInfo) string {
+			return okStyle.Render("  + ") + formatProject(p)
+		})
 	}
 
 	if len(data.Sleeping) > 0 {
-		b.WriteString(sectionStyle.Render(fmt.Sprintf("\nSchlafende Projekte (%d)", len(data.Sleeping))))
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("\n%s (%d)", i18n.T("section.sleeping"), len(data.Sleeping))))
 		b.WriteString("\n")
-		for _, p := range data.Sleeping {
-			b.WriteString(sleepStyle.Render("  ~ "))
-			b.WriteString(formatProject(p))
-			b.WriteString("\n")
-		}
+		writeProjects(&b, data.Sleeping, data.Groups, func(p claude.ProjectInfo) string {
+			return sleepStyle.Render("  ~ ") + formatProject(p)
+		})
 	}
 
 	if len(data.Acknowledged) > 0 {
-		b.WriteString(sectionStyle.Render(fmt.Sprintf("\nAcknowledged (%d)", len(data.Acknowledged))))
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("\n%s (%d)", i18n.T("section.acknowledged"), len(data.Acknowledged))))
 		b.WriteString("\n")
-		for _, p := range data.Acknowledged {
-			b.WriteString(dimStyle.Render("  ✓ "))
-			b.WriteString(dimStyle.Render(formatProjectAck(p)))
-			b.WriteString("\n")
-		}
+		writeProjects(&b, data.Acknowledged, data.Groups, func(p claude.ProjectInfo) string {
+			return dimStyle.Render("  ✓ ") + dimStyle.Render(formatProjectAck(p))
+		})
 	}
 
-	if len(data.OpenWork) == 0 && len(data.RecentActivity) == 0 && len(data.Sleeping) == 0 && len(data.Acknowledged) == 0 {
-		b.WriteString(dimStyle.Render("  Keine Projekte im gewaehlten Zeitraum gefunden."))
+	if len(data.Ignored) > 0 {
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("\n%s (%d)", i18n.T("section.ignored"), len(data.Ignored))))
+		b.WriteString("\n")
+		writeProjects(&b, data.Ignored, data.Groups, func(p claude.ProjectInfo) string {
+			return dimStyle.Render("  - ") + dimStyle.Render(formatProjectIgnored(p))
+		})
+	}
+
+	if len(data.OpenWork) == 0 && len(data.RecentActivity) == 0 && len(data.Sleeping) == 0 && len(data.Acknowledged) == 0 && len(data.Ignored) == 0 {
+		b.WriteString(dimStyle.Render("  " + i18n.T("empty")))
 		b.WriteString("\n")
 	}
 
@@ -93,61 +99,94 @@ func RenderTerminal(data analyzer.CategorizedProjects) string {
 func RenderProjectDetail(p claude.ProjectInfo, prompts []claude.HistoryEntry) string {
 	var b strings.Builder
 
-	b.WriteString(titleStyle.Render("Squirrel - Projekt-Detail"))
+	b.WriteString(titleStyle.Render(i18n.T("title.detail")))
 	b.WriteString("\n\n")
 
 	// Project metadata
-	b.WriteString(sectionStyle.Render("Projekt"))
+	b.WriteString(sectionStyle.Render(i18n.T("section.project")))
 	b.WriteString("\n")
-	b.WriteString(fmt.Sprintf("  Pfad:       %s\n", p.Path))
-	b.WriteString(fmt.Sprintf("  Name:       %s\n", p.ShortName))
+	writeField(&b, "label.path", p.Path)
+	writeField(&b, "label.name", p.ShortName)
+	if p.Host != "" {
+		writeField(&b, "label.host", p.Host)
+	}
+	if len(p.Profiles) > 0 {
+		writeField(&b, "label.profile", strings.Join(p.Profiles, ", "))
+	}
+	for _, alias := range p.Aliases {
+		writeField(&b, "label.alias", alias)
+	}
+	if p.Group != "" {
+		writeField(&b, "label.group", p.Group)
+	}
+	if len(p.Tags) > 0 {
+		writeField(&b, "label.tags", strings.Join(p.Tags, ", "))
+	}
 
 	branch := p.GitBranch
 	if branch == "" {
 		branch = p.LatestBranch
 	}
 	if branch != "" {
-		b.WriteString(fmt.Sprintf("  Branch:     %s\n", branch))
+		writeField(&b, "label.branch", branch)
 	}
 
 	if p.GitDirty {
-		b.WriteString(fmt.Sprintf("  Git-Status: %s\n", warnStyle.Render(fmt.Sprintf("%d uncommitted", p.UncommittedFiles))))
+		writeField(&b, "label.gitStatus", warnStyle.Render(i18n.T("project.uncommitted", p.UncommittedFiles)))
 	} else if p.UncommittedFiles == 0 && p.GitBranch != "" {
-		b.WriteString(fmt.Sprintf("  Git-Status: %s\n", okStyle.Render("clean")))
+		writeField(&b, "label.gitStatus", okStyle.Render(i18n.T("git.clean")))
 	}
 
-	b.WriteString(fmt.Sprintf("  Score:      %.1f\n", p.Score))
-	b.WriteString(fmt.Sprintf("  Prompts:    %d\n", p.PromptCount))
+	writeField(&b, "label.score", fmt.Sprintf("%.1f", p.Score))
+	writeField(&b, "label.prompts", fmt.Sprint(p.PromptCount))
 
 	// Activity period
 	b.WriteString("\n")
-	b.WriteString(sectionStyle.Render("Aktivitaet"))
+	b.WriteString(sectionStyle.Render(i18n.T("section.activity")))
 	b.WriteString("\n")
-	b.WriteString(fmt.Sprintf("  Erste:      %s\n", p.FirstActivity.Format("02.01.2006 15:04")))
-	b.WriteString(fmt.Sprintf("  Letzte:     %s\n", p.LastActivity.Format("02.01.2006 15:04")))
+	writeField(&b, "label.first", i18n.DateTime(p.FirstActivity))
+	writeField(&b, "label.last", i18n.DateTime(p.LastActivity))
 	if p.DaysSinceActive > 0 {
-		b.WriteString(fmt.Sprintf("  Inaktiv:    %d Tage\n", p.DaysSinceActive))
+		writeField(&b, "label.inactive", i18n.N("detail.days", p.DaysSinceActive))
+	}
+
+	// Notes, newest first
+	if len(p.Notes) > 0 {
+		b.WriteString("\n")
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("%s (%d)", i18n.T("section.notes"), len(p.Notes))))
+		b.WriteString("\n")
+		for i, n := range p.Notes {
+			text := n.Text
+			if i == 0 {
+				text = warnStyle.Render(text)
+			}
+			line := fmt.Sprintf("  %s  %s", dimStyle.Render(i18n.DateTime(n.CreatedAt)), text)
+			if n.RemindAt != nil {
+				line += dimStyle.Render("  " + i18n.T("note.reminder", i18n.Date(*n.RemindAt)))
+			}
+			b.WriteString(line + "\n")
+		}
 	}
 
 	// Sessions
 	if len(p.Sessions) > 0 {
 		b.WriteString("\n")
-		b.WriteString(sectionStyle.Render(fmt.Sprintf("Sessions (%d)", len(p.Sessions))))
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("%s (%d)", i18n.T("section.sessions"), len(p.Sessions))))
 		b.WriteString("\n")
 		for _, s := range p.Sessions {
 			summary := s.Summary
 			if summary == "" {
 				summary = s.FirstPrompt
 			}
-			summary = truncate(summary, 60)
+			summary = fitText(summary, 26, 60)
 			date := s.Modified
 			if len(date) > 10 {
 				date = date[:10]
 			}
-			b.WriteString(fmt.Sprintf("  %s  %s  %d msgs\n",
+			b.WriteString(fmt.Sprintf("  %s  %s  %s\n",
 				dimStyle.Render(date),
 				summary,
-				s.MsgCount,
+				i18n.T("session.msgs", s.MsgCount),
 			))
 		}
 	}
@@ -155,11 +194,11 @@ func RenderProjectDetail(p claude.ProjectInfo, prompts []claude.HistoryEntry) st
 	// Recent prompts from history
 	if len(prompts) > 0 {
 		b.WriteString("\n")
-		b.WriteString(sectionStyle.Render(fmt.Sprintf("Letzte Prompts (%d)", len(prompts))))
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("%s (%d)", i18n.T("section.prompts"), len(prompts))))
 		b.WriteString("\n")
 		for _, pr := range prompts {
-			ts := time.UnixMilli(pr.Timestamp).Format("02.01. 15:04")
-			display := truncate(pr.Display, 70)
+			ts := i18n.ShortDateTime(time.UnixMilli(pr.Timestamp))
+			display := fitText(pr.Display, 16, 70)
 			b.WriteString(fmt.Sprintf("  %s  %s\n", dimStyle.Render(ts), display))
 		}
 	}
@@ -167,7 +206,7 @@ func RenderProjectDetail(p claude.ProjectInfo, prompts []claude.HistoryEntry) st
 	// TODOs from deep mode
 	if len(p.Todos) > 0 {
 		b.WriteString("\n")
-		b.WriteString(sectionStyle.Render(fmt.Sprintf("TODOs (%d)", len(p.Todos))))
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("%s (%d)", i18n.T("section.todos"), len(p.Todos))))
 		b.WriteString("\n")
 		for _, todo := range p.Todos {
 			b.WriteString(fmt.Sprintf("  %s %s\n",
@@ -180,22 +219,92 @@ func RenderProjectDetail(p claude.ProjectInfo, prompts []claude.HistoryEntry) st
 	return b.String()
 }
 
+// writeProjects writes one line per project. When groups are in use, projects
+// are listed under a header per group, followed by the ungrouped ones.
+func writeProjects(b *strings.Builder, projects []claude.ProjectInfo, groups []string, line func(claude.ProjectInfo) string) {
+	if len(groups) == 0 {
+		for _, p := range projects {
+			b.WriteString(line(p))
+			b.WriteString("\n")
+		}
+		return
+	}
+
+	for _, g := range append(slices.Clone(groups), "") {
+		var members []claude.ProjectInfo
+		for _, p := range projects {
+			if p.Group == g {
+				members = append(members, p)
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		header := g
+		if header == "" {
+			header = i18n.T("group.none")
+		}
+		b.WriteString(groupStyle.Render(fmt.Sprintf("  [%s]", header)))
+		b.WriteString("\n")
+		for _, p := range members {
+			b.WriteString("  ")
+			b.WriteString(line(p))
+			b.WriteString("\n")
+		}
+	}
+}
+
 func formatProjectAck(p claude.ProjectInfo) string {
-	date := p.LastActivity.Format("02.01.")
-	name := fmt.Sprintf("%-22s", truncate(p.ShortName, 22))
-	prompts := fmt.Sprintf("%4d prompts", p.PromptCount)
-	return strings.Join([]string{name, date, prompts}, " | ")
+	date := shortDate(p.LastActivity)
+	name := pad(truncate(p.ShortName, nameWidth()), nameWidth())
+	prompts := i18n.N("project.prompts", p.PromptCount)
+	details := []string{name, date, prompts}
+	if p.AckedBy != "" {
+		details = append(details, i18n.T("project.rule", p.AckedBy))
+	}
+	return joinColumns(details)
+}
+
+func formatProjectIgnored(p claude.ProjectInfo) string {
+	date := shortDate(p.LastActivity)
+	name := pad(truncate(p.ShortName, nameWidth()), nameWidth())
+	prompts := i18n.N("project.prompts", p.PromptCount)
+	return joinColumns([]string{name, date, prompts, i18n.T("project.ignored", p.IgnoredBy)})
 }
 
 func formatProject(p claude.ProjectInfo) string {
-	date := p.LastActivity.Format("02.01.")
-	name := fmt.Sprintf("%-22s", truncate(p.ShortName, 22))
-	prompts := fmt.Sprintf("%4d prompts", p.PromptCount)
+	date := shortDate(p.LastActivity)
+	name := pad(truncate(p.ShortName, nameWidth()), nameWidth())
+	prompts := i18n.N("project.prompts", p.PromptCount)
 
 	details := []string{name, date, prompts}
 
+	if len(p.Activity) > 0 {
+		details = append(details, okStyle.Render(Sparkline(p.Activity)))
+	}
+
+	if p.ReminderDue && len(p.Notes) > 0 {
+		details = append(details, warnStyle.Render(i18n.T("project.reminder", truncate(p.Notes[0].Text, 40))))
+	}
+
+	if p.WokenBy != "" {
+		details = append(details, warnStyle.Render(i18n.T("project.woken", p.WokenBy)))
+	}
+
+	if p.Host != "" {
+		details = append(details, dimStyle.Render("@"+p.Host))
+	}
+
+	if len(p.Profiles) > 0 {
+		details = append(details, dimStyle.Render("["+strings.Join(p.Profiles, ",")+"]"))
+	}
+
+	if len(p.Tags) > 0 {
+		details = append(details, dimStyle.Render("#"+strings.Join(p.Tags, " #")))
+	}
+
 	if p.UncommittedFiles > 0 {
-		details = append(details, warnStyle.Render(fmt.Sprintf("%d uncommitted", p.UncommittedFiles)))
+		details = append(details, warnStyle.Render(i18n.T("project.uncommitted", p.UncommittedFiles)))
 	}
 
 	branch := p.GitBranch
@@ -203,19 +312,22 @@ func formatProject(p claude.ProjectInfo) string {
 		branch = p.LatestBranch
 	}
 	if branch != "" && branch != "main" && branch != "master" {
-		details = append(details, dimStyle.Render("branch: "+branch))
+		details = append(details, dimStyle.Render(i18n.T("project.branch", branch)))
 	}
 
 	if p.DaysSinceActive > 0 {
-		details = append(details, dimStyle.Render(fmt.Sprintf("%d Tage inaktiv", p.DaysSinceActive)))
+		details = append(details, dimStyle.Render(i18n.N("project.inactive", p.DaysSinceActive)))
 	}
 
-	return strings.Join(details, " | ")
+	return joinColumns(details)
 }
 
-func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-1] + "~"
+// writeField writes one aligned "label: value" line of the detail view.
+func writeField(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "  %-12s%s\n", i18n.T(key)+":", value)
+}
+
+// shortDate pads the localized short date so list columns stay aligned.
+func shortDate(t time.Time) string {
+	return pad(i18n.ShortDate(t), 6)
 }
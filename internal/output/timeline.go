@@ -0,0 +1,120 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/i18n"
+)
+
+// timelineSessions is the number of sessions listed per project and bucket
+// in terminal output.
+const timelineSessions = 3
+
+// RenderTimeline renders timeline buckets as styled terminal output.
+func RenderTimeline(buckets []analyzer.TimelineBucket, by string) string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(i18n.T("timeline.title")))
+	b.WriteString("\n\n")
+
+	for i, bucket := range buckets {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(sectionStyle.Render(bucketTitle(bucket, by)))
+		b.WriteString("\n")
+		for _, tp := range bucket.Projects {
+			name := pad(truncate(tp.Project.ShortName, nameWidth()), nameWidth())
+			prompts := i18n.N("project.prompts", tp.Prompts)
+			last := i18n.ShortDateTime(tp.LastPrompt)
+			if by == "day" {
+				last = tp.LastPrompt.Format("15:04")
+			}
+			fmt.Fprintf(&b, "  %s\n", joinColumns([]string{name, prompts, dimStyle.Render(last)}))
+
+			for j, s := range tp.Sessions {
+				if j == timelineSessions {
+					b.WriteString(dimStyle.Render("      " + i18n.N("timeline.more", len(tp.Sessions)-timelineSessions)))
+					b.WriteString("\n")
+					break
+				}
+				b.WriteString(dimStyle.Render(fmt.Sprintf("      %s (%s)", fitText(sessionTitle(s.Summary, s.FirstPrompt), 20, 60), i18n.T("session.msgs", s.MsgCount))))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	if len(buckets) == 0 {
+		b.WriteString(dimStyle.Render("  " + i18n.T("empty")))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// RenderTimelineMarkdown renders timeline buckets as one Markdown table per
+// bucket.
+func RenderTimelineMarkdown(buckets []analyzer.TimelineBucket, by string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n", i18n.T("timeline.title"))
+
+	for _, bucket := range buckets {
+		fmt.Fprintf(&b, "\n## %s\n\n", bucketTitle(bucket, by))
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n|---|--:|---|---|\n",
+			i18n.T("label.project"), i18n.T("label.prompts"), i18n.T("label.lastPrompt"), i18n.T("label.sessions"))
+		for _, tp := range bucket.Projects {
+			var sessions []string
+			for _, s := range tp.Sessions {
+				sessions = append(sessions, mdEscape(truncate(sessionTitle(s.Summary, s.FirstPrompt), 60)))
+			}
+			fmt.Fprintf(&b, "| %s | %d | %s | %s |\n",
+				mdEscape(tp.Project.ShortName),
+				tp.Prompts,
+				i18n.DateTime(tp.LastPrompt),
+				strings.Join(sessions, "<br>"),
+			)
+		}
+	}
+
+	if len(buckets) == 0 {
+		fmt.Fprintf(&b, "\n_%s_\n", i18n.T("empty"))
+	}
+
+	return b.String()
+}
+
+// RenderTimelineJSON returns timeline buckets as a TimelineDocument JSON string.
+func RenderTimelineJSON(buckets []analyzer.TimelineBucket, by string) (string, error) {
+	b, err := json.MarshalIndent(NewTimelineDocument(buckets, by), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// bucketTitle names a bucket, e.g. "Mon, Mar 2, 2026 (12 prompts)",
+// "Week of Mar 2, 2026 (40 prompts)" or "March 2026 (150 prompts)".
+func bucketTitle(bucket analyzer.TimelineBucket, by string) string {
+	var label string
+	switch by {
+	case "week":
+		label = i18n.T("timeline.week", i18n.Date(bucket.Start))
+	case "month":
+		label = fmt.Sprintf("%s %d", i18n.Month(bucket.Start), bucket.Start.Year())
+	default:
+		label = i18n.Weekday(bucket.Start) + ", " + i18n.Date(bucket.Start)
+	}
+	return i18n.T("timeline.bucket", label, i18n.N("timeline.prompts", bucket.Prompts))
+}
+
+// sessionTitle is a session's summary, falling back to its first prompt.
+func sessionTitle(summary, firstPrompt string) string {
+	if summary != "" {
+		return summary
+	}
+	return firstPrompt
+}
@@ -0,0 +1,207 @@
+package syncstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/internal/config"
+)
+
+// Snapshot is the state one machine publishes to the sync directory.
+// Every host writes only its own file, so a shared folder or a git repo
+// never sees conflicting writes.
+type Snapshot struct {
+	Host         string               `json:"host"`
+	ExportedAt   time.Time            `json:"exportedAt"`
+	Acknowledged []config.AckEntry    `json:"acknowledged"`
+	Unacked      []config.Tombstone   `json:"unacked,omitempty"`
+	Notes        []config.NoteEntry   `json:"notes,omitempty"`
+	Projects     []claude.ProjectInfo `json:"projects"`
+	// Identities holds the git identities of the host's project paths
+	// that are repositories, so that other hosts can merge them by
+	// mergeBy without the checkouts.
+	Identities map[string]GitIdentity `json:"identities,omitempty"`
+}
+
+// GitIdentity identifies a repository across clones and machines.
+type GitIdentity struct {
+	Remote     string `json:"remote,omitempty"`
+	RootCommit string `json:"rootCommit,omitempty"`
+}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Hostname returns the name this machine uses in the sync directory:
+// the configured host override, or the system hostname.
+func Hostname(cfg *config.Config) string {
+	if cfg.Host != "" {
+		return cfg.Host
+	}
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return "localhost"
+	}
+	return strings.TrimSuffix(host, ".local")
+}
+
+// FileName returns the snapshot file name for host.
+func FileName(host string) string {
+	return unsafeChars.ReplaceAllString(host, "_") + ".json"
+}
+
+// Write stores the snapshot as <dir>/<host>.json, replacing it atomically.
+func Write(dir string, s Snapshot) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating sync directory: %w", err)
+	}
+
+	// Session details and deep-mode data stay local; only the overview is shared
+	for i := range s.Projects {
+		s.Projects[i].Sessions = nil
+		s.Projects[i].Todos = nil
+		s.Projects[i].LastMessages = nil
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling snapshot: %w", err)
+	}
+
+	path := filepath.Join(dir, FileName(s.Host))
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReadAll reads every snapshot in dir, sorted by host. Files that cannot be
+// parsed are skipped so one broken machine does not block the others.
+func ReadAll(dir string) ([]Snapshot, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		var s Snapshot
+		if err := json.Unmarshal(data, &s); err != nil || s.Host == "" {
+			continue
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Host < snapshots[j].Host
+	})
+	return snapshots, nil
+}
+
+// Import merges the acknowledgements and notes of all other hosts' snapshots
+// into cfg, mapping their paths through cfg's path rewrites as RemoteProjects
+// does. Returns the number of changes applied.
+func Import(cfg *config.Config, snapshots []Snapshot, self string) int {
+	changed := 0
+	for _, s := range snapshots {
+		if s.Host == self {
+			continue
+		}
+		acks := make([]config.AckEntry, len(s.Acknowledged))
+		for i, a := range s.Acknowledged {
+			a.Path = cfg.RewritePath(a.Path)
+			acks[i] = a
+		}
+		unacked := make([]config.Tombstone, len(s.Unacked))
+		for i, t := range s.Unacked {
+			t.Path = cfg.RewritePath(t.Path)
+			unacked[i] = t
+		}
+		notes := make([]config.NoteEntry, len(s.Notes))
+		for i, n := range s.Notes {
+			n.Path = cfg.RewritePath(n.Path)
+			notes[i] = n
+		}
+		changed += cfg.MergeAcks(acks, unacked)
+		changed += cfg.MergeNotes(notes)
+	}
+	return changed
+}
+
+// Identities collects the git identities other hosts recorded, keyed by
+// their paths mapped through rewrite (may be nil). Where hosts disagree the
+// most recent export wins.
+func Identities(snapshots []Snapshot, self string, rewrite func(string) string) map[string]GitIdentity {
+	ids := make(map[string]GitIdentity)
+	exported := make(map[string]time.Time)
+	for _, s := range snapshots {
+		if s.Host == self {
+			continue
+		}
+		for path, id := range s.Identities {
+			if rewrite != nil {
+				path = rewrite(path)
+			}
+			if at, ok := exported[path]; ok && !s.ExportedAt.After(at) {
+				continue
+			}
+			ids[path], exported[path] = id, s.ExportedAt
+		}
+	}
+	return ids
+}
+
+// RemoteProjects returns projects from other hosts' snapshots that were last
+// active in [since, until) and are not already known locally. Paths are mapped
+// through rewrite (may be nil) first. Each project is marked with its Host; a
+// project present on several hosts is taken from the most recently active one.
+func RemoteProjects(snapshots []Snapshot, self string, local []claude.ProjectInfo, rewrite func(string) string, since, until time.Time) []claude.ProjectInfo {
+	known := make(map[string]bool)
+	for _, p := range local {
+		for _, path := range p.Paths() {
+			known[path] = true
+		}
+	}
+
+	index := make(map[string]int)
+	var remote []claude.ProjectInfo
+	for _, s := range snapshots {
+		if s.Host == self {
+			continue
+		}
+		for _, p := range s.Projects {
+			if p.LastActivity.Before(since) || !p.LastActivity.Before(until) {
+				continue
+			}
+			if rewrite != nil {
+				p.Path = rewrite(p.Path)
+			}
+			if known[p.Path] {
+				continue
+			}
+			p.Host = s.Host
+			p.DaysSinceActive = int(until.Sub(p.LastActivity).Hours() / 24)
+
+			if i, ok := index[p.Path]; ok {
+				if p.LastActivity.After(remote[i].LastActivity) {
+					remote[i] = p
+				}
+				continue
+			}
+			index[p.Path] = len(remote)
+			remote = append(remote, p)
+		}
+	}
+	return remote
+}
@@ -0,0 +1,157 @@
+package syncstore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/internal/config"
+)
+
+func TestWriteReadAllRoundtrip(t *testing.T) {
+	dir := t.TempDir()
+
+	snap := Snapshot{
+		Host:         "mac.example",
+		ExportedAt:   time.Now(),
+		Acknowledged: []config.AckEntry{{Path: "/Users/me/src/x", AckedAt: time.Now()}},
+		Projects: []claude.ProjectInfo{
+			{Path: "/Users/me/src/x", ShortName: "x", LastMessages: []string{"secret"}, Sessions: []claude.SessionEntry{{SessionID: "1"}}},
+		},
+	}
+	if err := Write(dir, snap); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := Write(dir, Snapshot{Host: "linux", ExportedAt: time.Now()}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{not json"), 0644)
+
+	snapshots, err := ReadAll(dir)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots (broken file skipped), got %d", len(snapshots))
+	}
+	if snapshots[0].Host != "linux" || snapshots[1].Host != "mac.example" {
+		t.Errorf("expected snapshots sorted by host, got %q, %q", snapshots[0].Host, snapshots[1].Host)
+	}
+
+	p := snapshots[1].Projects[0]
+	if len(p.LastMessages) != 0 || len(p.Sessions) != 0 {
+		t.Error("session details should not be exported")
+	}
+}
+
+func TestImportSkipsOwnHost(t *testing.T) {
+	cfg := &config.Config{}
+	snapshots := []Snapshot{
+		{Host: "self", Acknowledged: []config.AckEntry{{Path: "/a", AckedAt: time.Now()}}},
+		{Host: "other", Acknowledged: []config.AckEntry{{Path: "/b", AckedAt: time.Now()}},
+			Notes: []config.NoteEntry{{Path: "/b", Text: "next: deploy", CreatedAt: time.Now()}}},
+	}
+
+	if n := Import(cfg, snapshots, "self"); n != 2 {
+		t.Errorf("expected 2 imported changes, got %d", n)
+	}
+	if len(cfg.Notes) != 1 {
+		t.Errorf("expected other host's note to be imported, got %d notes", len(cfg.Notes))
+	}
+	if cfg.IsAcknowledged("/a") {
+		t.Error("own snapshot should not be imported")
+	}
+	if !cfg.IsAcknowledged("/b") {
+		t.Error("other host's ack should be imported")
+	}
+}
+
+func TestImportRewritesPaths(t *testing.T) {
+	cfg := &config.Config{PathRewrites: []config.PathRewrite{{From: "/home/me", To: "/Users/me"}}}
+	acked := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []Snapshot{{
+		Host:         "linux",
+		Acknowledged: []config.AckEntry{{Path: "/home/me/src/x", AckedAt: acked}},
+		Unacked:      []config.Tombstone{{Path: "/home/me/src/y", RemovedAt: acked}},
+		Notes:        []config.NoteEntry{{Path: "/home/me/src/x", Text: "next: deploy", CreatedAt: acked}},
+	}}
+
+	Import(cfg, snapshots, "mac")
+	if !cfg.IsAcknowledged("/Users/me/src/x") || cfg.IsAcknowledged("/home/me/src/x") {
+		t.Errorf("acknowledged = %+v, want the rewritten path", cfg.Acknowledged)
+	}
+	if len(cfg.Unacked) != 1 || cfg.Unacked[0].Path != "/Users/me/src/y" {
+		t.Errorf("unacked = %+v, want the rewritten path", cfg.Unacked)
+	}
+	if len(cfg.Notes) != 1 || cfg.Notes[0].Path != "/Users/me/src/x" {
+		t.Errorf("notes = %+v, want the rewritten path", cfg.Notes)
+	}
+	if snapshots[0].Acknowledged[0].Path != "/home/me/src/x" {
+		t.Error("Import modified the snapshot")
+	}
+
+	// A second import changes nothing
+	if n := Import(cfg, snapshots, "mac"); n != 0 {
+		t.Errorf("re-import applied %d changes, want 0", n)
+	}
+}
+
+func TestRemoteProjects(t *testing.T) {
+	now := time.Now()
+	snapshots := []Snapshot{
+		{Host: "mac", Projects: []claude.ProjectInfo{
+			{Path: "/Users/me/src/shared", LastActivity: now},
+			{Path: "/Users/me/src/mac-only", LastActivity: now.Add(-48 * time.Hour)},
+			{Path: "/Users/me/src/ancient", LastActivity: now.AddDate(0, 0, -100)},
+		}},
+		{Host: "self", Projects: []claude.ProjectInfo{
+			{Path: "/home/me/src/own", LastActivity: now},
+		}},
+	}
+	local := []claude.ProjectInfo{{Path: "/home/me/src/shared"}}
+	rewrite := func(p string) string { return strings.Replace(p, "/Users/me", "/home/me", 1) }
+
+	remote := RemoteProjects(snapshots, "self", local, rewrite, now.AddDate(0, 0, -14), now.Add(time.Minute))
+
+	if len(remote) != 1 {
+		t.Fatalf("expected 1 remote-only project, got %d: %+v", len(remote), remote)
+	}
+	if remote[0].Path != "/home/me/src/mac-only" || remote[0].Host != "mac" {
+		t.Errorf("unexpected remote project %+v", remote[0])
+	}
+	if remote[0].DaysSinceActive != 2 {
+		t.Errorf("expected 2 days since active, got %d", remote[0].DaysSinceActive)
+	}
+}
+
+func TestIdentities(t *testing.T) {
+	now := time.Now()
+	snapshots := []Snapshot{
+		{Host: "mac", ExportedAt: now.Add(-time.Hour), Identities: map[string]GitIdentity{
+			"/Users/me/src/app": {Remote: "github.com/team/app"},
+			"/Users/me/src/lib": {RootCommit: "old"},
+		}},
+		{Host: "laptop", ExportedAt: now, Identities: map[string]GitIdentity{
+			"/Users/me/src/lib": {RootCommit: "new"},
+		}},
+		{Host: "self", ExportedAt: now, Identities: map[string]GitIdentity{
+			"/home/me/src/own": {Remote: "github.com/me/own"},
+		}},
+	}
+	rewrite := func(p string) string { return strings.Replace(p, "/Users/me", "/home/me", 1) }
+
+	ids := Identities(snapshots, "self", rewrite)
+
+	if len(ids) != 2 {
+		t.Fatalf("identities = %+v, want app and lib from other hosts", ids)
+	}
+	if ids["/home/me/src/app"].Remote != "github.com/team/app" {
+		t.Errorf("app = %+v, want its remote under the rewritten path", ids["/home/me/src/app"])
+	}
+	if ids["/home/me/src/lib"].RootCommit != "new" {
+		t.Errorf("lib = %+v, want the newest export's", ids["/home/me/src/lib"])
+	}
+}
@@ -0,0 +1,287 @@
+package squirrel
+
+import (
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/config"
+)
+
+// Config is a squirrel config: acknowledgements, notes, tags, groups, the
+// ignore list and merge rules. The zero value is an empty config. Changes
+// are seen by later analyses of a Squirrel following the config; save them
+// with UpdateConfig.
+type Config struct {
+	c *config.Config
+}
+
+// ErrUnchanged makes UpdateConfig skip saving without failing.
+var ErrUnchanged = config.ErrUnchanged
+
+// WakeEvents lists the events that end a snooze early: "prompts" (new
+// prompts in the project), "dirty" (the repository became dirty),
+// "remote" (a remote-tracking branch moved) and "branch" (a new local
+// branch).
+var WakeEvents = config.WakeEvents
+
+// DefaultConfigPath returns the default config file,
+// $XDG_CONFIG_HOME/squirrel/config.json.
+func DefaultConfigPath() string {
+	return config.DefaultPath()
+}
+
+// LoadConfig reads the config at path; a missing file is an empty config.
+func LoadConfig(path string) (*Config, error) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{c: cfg}, nil
+}
+
+// UpdateConfig loads the config at path, applies fn and saves the result
+// while holding a lock, so concurrent squirrel processes cannot lose each
+// other's changes. Nothing is written if fn returns an error; returning
+// ErrUnchanged skips the write without failing.
+func UpdateConfig(path string, fn func(*Config) error) error {
+	return config.Update(path, func(cfg *config.Config) error {
+		return fn(&Config{c: cfg})
+	})
+}
+
+// RestoreConfig replaces the config at path with the backup UpdateConfig
+// took before its last write, ConfigBackupPath. The replaced file is kept
+// next to it with a ".corrupt" suffix for inspection.
+func RestoreConfig(path string) error {
+	return config.Restore(path)
+}
+
+// ConfigBackupPath returns where UpdateConfig keeps the previous version of
+// the config at path.
+func ConfigBackupPath(path string) string {
+	return config.BackupPath(path)
+}
+
+// ParseDuration parses durations of days, weeks and months like "7d", "2w"
+// or "3m", as used for acknowledgements and reminders.
+func ParseDuration(s string) (time.Duration, error) {
+	return config.ParseDuration(s)
+}
+
+// ValidateWakeOn checks that all events are WakeEvents.
+func ValidateWakeOn(events []string) error {
+	return config.ValidateWakeOn(events)
+}
+
+// IsGlob reports whether an ignore list entry or group pattern is a glob
+// rather than a path.
+func IsGlob(s string) bool {
+	return config.IsGlob(s)
+}
+
+// config returns the underlying config, creating it for the zero value.
+func (c *Config) config() *config.Config {
+	if c.c == nil {
+		c.c = &config.Config{}
+	}
+	return c.c
+}
+
+// Ack acknowledges the project at path until expiresAt; nil is permanent.
+func (c *Config) Ack(path string, expiresAt *time.Time) {
+	c.config().Ack(path, expiresAt)
+}
+
+// Snooze acknowledges the project at path until expiresAt (nil is
+// permanent) or until one of the wakeOn events happens relative to state,
+// see Squirrel.AckState.
+func (c *Config) Snooze(path string, expiresAt *time.Time, wakeOn []string, state AckSnapshot) {
+	snapshot := toAckSnapshot(state)
+	c.config().Snooze(path, expiresAt, wakeOn, &snapshot)
+}
+
+// Unack removes the acknowledgement of the project at path and reports
+// whether there was one.
+func (c *Config) Unack(path string) bool {
+	return c.config().Unack(path)
+}
+
+// Acks returns the explicit acknowledgements.
+func (c *Config) Acks() []Ack {
+	return convert(c.config().Acknowledged, func(a config.AckEntry) Ack {
+		return Ack{Path: a.Path, ExpiresAt: a.ExpiresAt, WakeOn: a.WakeOn}
+	})
+}
+
+// AckRules returns the ack rules.
+func (c *Config) AckRules() []AckRule {
+	return convert(c.config().AckRules, fromAckRule)
+}
+
+// AddAckRule adds rule, replacing a rule with the same pattern.
+func (c *Config) AddAckRule(rule AckRule) error {
+	return c.config().AddAckRule(toAckRule(rule))
+}
+
+// RemoveAckRule removes the rules with pattern and reports whether there
+// were any.
+func (c *Config) RemoveAckRule(pattern string) bool {
+	return c.config().RemoveAckRule(pattern)
+}
+
+// AddNote leaves a note on the project at path, resurfacing the project at
+// remindAt unless that is nil.
+func (c *Config) AddNote(path, text string, remindAt *time.Time) {
+	c.config().AddNote(path, text, remindAt)
+}
+
+// NotesFor returns the notes on the given paths, a project's Paths,
+// newest first.
+func (c *Config) NotesFor(paths ...string) []Note {
+	return convert(c.config().NotesFor(paths...), func(n config.NoteEntry) Note {
+		return Note{Text: n.Text, CreatedAt: n.CreatedAt, RemindAt: n.RemindAt}
+	})
+}
+
+// TagsFor returns the tags of the given paths, a project's Paths.
+func (c *Config) TagsFor(paths ...string) []string {
+	return c.config().TagsFor(paths...)
+}
+
+// Tag adds tags to the project at path and returns those it did not have.
+func (c *Config) Tag(path string, tags ...string) []string {
+	return c.config().Tag(path, tags...)
+}
+
+// Untag removes tags, or all tags if none are given, from the project at
+// path and returns how many were removed.
+func (c *Config) Untag(path string, tags ...string) int {
+	return c.config().Untag(path, tags...)
+}
+
+// Ignore returns the ignore list: exact paths and path globs.
+func (c *Config) Ignore() []string {
+	return c.config().Ignore
+}
+
+// AddIgnore adds a path or path glob to the ignore list and reports
+// whether it was new.
+func (c *Config) AddIgnore(entry string) bool {
+	return c.config().AddIgnore(entry)
+}
+
+// RemoveIgnore removes entry from the ignore list and reports whether it
+// was there.
+func (c *Config) RemoveIgnore(entry string) bool {
+	return c.config().RemoveIgnore(entry)
+}
+
+// SyncDir returns the directory Squirrel.Sync shares state through.
+func (c *Config) SyncDir() string {
+	return c.config().SyncDir
+}
+
+// SetSyncDir sets the directory Squirrel.Sync shares state through.
+func (c *Config) SetSyncDir(dir string) {
+	c.config().SyncDir = dir
+}
+
+// Reset replaces the contents of c with those of src, e.g. to save an
+// edited copy through UpdateConfig.
+func (c *Config) Reset(src *Config) {
+	*c.config() = *src.config()
+}
+
+// ConfigKey is a parsed settings key: a top-level setting ("syncDir",
+// "host"), a default for a flag of any command ("defaults.<flag>") or of
+// one command ("commands.<command>.<flag>").
+type ConfigKey struct {
+	// Name is the top-level setting, or "" for flag defaults.
+	Name    string
+	Command string
+	Flag    string
+}
+
+// ParseConfigKey splits a dotted settings key.
+func ParseConfigKey(key string) (ConfigKey, error) {
+	k, err := config.ParseKey(key)
+	return ConfigKey(k), err
+}
+
+// Setting is a settings key and its value, as listed by Config.Settings.
+type Setting struct {
+	Key   string
+	Value string
+}
+
+// Get returns the value stored under k.
+func (c *Config) Get(k ConfigKey) (string, bool) {
+	return c.config().Get(config.Key(k))
+}
+
+// Set stores value under k. An empty value removes the setting.
+func (c *Config) Set(k ConfigKey, value string) {
+	c.config().Set(config.Key(k), value)
+}
+
+// Settings lists the top-level settings, then the global and the
+// per-command flag defaults, each sorted by key.
+func (c *Config) Settings() []Setting {
+	return convert(c.config().Settings(), func(s config.Setting) Setting { return Setting(s) })
+}
+
+// FlagDefault returns the default for flag when running command; the
+// command's own defaults win over the global ones.
+func (c *Config) FlagDefault(command, flag string) (string, bool) {
+	return c.config().FlagDefault(command, flag)
+}
+
+// Ack is an explicit acknowledgement.
+type Ack struct {
+	Path      string
+	ExpiresAt *time.Time
+	// WakeOn lists the WakeEvents that end a snooze.
+	WakeOn []string
+}
+
+// AckRule acknowledges every project whose path matches Pattern and which
+// meets all of the rule's conditions.
+type AckRule struct {
+	// Pattern is a path glob, or a regular expression if Regex is set.
+	Pattern string
+	Regex   bool
+	// NotGitRepo restricts the rule to directories that are not git
+	// repositories.
+	NotGitRepo bool
+	// MaxPrompts restricts the rule to projects with fewer prompts.
+	MaxPrompts int
+	// HomeRoot restricts the rule to the home directory and its children.
+	HomeRoot  bool
+	ExpiresAt *time.Time
+}
+
+// String describes the rule, e.g. "/tmp/* (not a git repo, < 3 prompts)".
+func (r AckRule) String() string {
+	return toAckRule(r).String()
+}
+
+func fromAckRule(r config.AckRule) AckRule {
+	return AckRule{
+		Pattern:    r.Pattern,
+		Regex:      r.Regex,
+		NotGitRepo: r.NotGitRepo,
+		MaxPrompts: r.MaxPrompts,
+		HomeRoot:   r.HomeRoot,
+		ExpiresAt:  r.ExpiresAt,
+	}
+}
+
+func toAckRule(r AckRule) config.AckRule {
+	return config.AckRule{
+		Pattern:    r.Pattern,
+		Regex:      r.Regex,
+		NotGitRepo: r.NotGitRepo,
+		MaxPrompts: r.MaxPrompts,
+		HomeRoot:   r.HomeRoot,
+		ExpiresAt:  r.ExpiresAt,
+	}
+}
@@ -0,0 +1,352 @@
+// Package fixture generates synthetic Claude data directories and matching
+// git repositories, for benchmarks, tests and trying squirrel without real
+// data. Output is deterministic for a given Options.
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+)
+
+// Options are the size knobs of a generated data set. Zero values take the
+// defaults of DefaultOptions.
+type Options struct {
+	// Projects is the number of projects.
+	Projects int
+	// Prompts is the number of history prompts per project.
+	Prompts int
+	// Sessions is the number of sessions per project.
+	Sessions int
+	// Messages is the number of messages per session.
+	Messages int
+	// Days is how far back the history reaches from Now.
+	Days int
+	// Repos creates a git repository for each project, in the states of
+	// RepoStates in turn. Without it project directories don't exist.
+	Repos bool
+	// Seed selects the generated content.
+	Seed uint64
+	// Now is the end of the generated history; zero means time.Now.
+	Now time.Time
+}
+
+// DefaultOptions is a small data set: 10 projects with 50 prompts and 3
+// sessions of 40 messages each over 30 days.
+var DefaultOptions = Options{Projects: 10, Prompts: 50, Sessions: 3, Messages: 40, Days: 30, Seed: 1}
+
+// RepoStates are the states of generated repositories: a clean and a dirty
+// repository on main, clean and dirty ones on a feature branch, a directory
+// that is no repository and a path that does not exist.
+var RepoStates = []string{"clean", "dirty", "feature", "feature-dirty", "none", "missing"}
+
+// Fixture describes a generated data set.
+type Fixture struct {
+	// ClaudeDir holds history.jsonl and projects/, like ~/.claude.
+	ClaudeDir string
+	Projects  []Project
+}
+
+// Project is a generated project.
+type Project struct {
+	Path string
+	// State is one of RepoStates, or "" without Options.Repos.
+	State    string
+	Sessions []string // session IDs
+}
+
+// Generate writes a data set to dir: the Claude data directory to
+// dir/claude and the projects to dir/repos.
+func Generate(dir string, opts Options) (*Fixture, error) {
+	opts = opts.withDefaults()
+	g := &generator{
+		opts: opts,
+		rnd:  rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x5eed)),
+		fx:   &Fixture{ClaudeDir: filepath.Join(dir, "claude")},
+	}
+	if err := os.MkdirAll(filepath.Join(g.fx.ClaudeDir, "projects"), 0o755); err != nil {
+		return nil, err
+	}
+
+	var history []claude.HistoryEntry
+	for i := range opts.Projects {
+		p := Project{Path: filepath.Join(dir, "repos", fmt.Sprintf("%s-%03d", words[i%len(words)], i))}
+		if opts.Repos {
+			p.State = RepoStates[i%len(RepoStates)]
+			if err := createRepo(p.Path, p.State); err != nil {
+				return nil, fmt.Errorf("creating repository %s: %w", p.Path, err)
+			}
+		}
+
+		entries := g.prompts(p.Path)
+		history = append(history, entries...)
+		ids, err := g.sessions(p.Path, entries)
+		if err != nil {
+			return nil, err
+		}
+		p.Sessions = ids
+		g.fx.Projects = append(g.fx.Projects, p)
+	}
+
+	// history.jsonl is in chronological order, with projects interleaved
+	sort.SliceStable(history, func(i, j int) bool { return history[i].Timestamp < history[j].Timestamp })
+	if err := writeJSONL(filepath.Join(g.fx.ClaudeDir, "history.jsonl"), history); err != nil {
+		return nil, err
+	}
+	return g.fx, nil
+}
+
+func (o Options) withDefaults() Options {
+	d := DefaultOptions
+	if o.Projects <= 0 {
+		o.Projects = d.Projects
+	}
+	if o.Prompts <= 0 {
+		o.Prompts = d.Prompts
+	}
+	if o.Sessions < 0 {
+		o.Sessions = 0
+	}
+	if o.Messages <= 0 {
+		o.Messages = d.Messages
+	}
+	if o.Days <= 0 {
+		o.Days = d.Days
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	return o
+}
+
+type generator struct {
+	opts Options
+	rnd  *rand.Rand
+	fx   *Fixture
+}
+
+// prompts returns the project's history entries, oldest first. The last
+// prompt of each project falls on a random day of the range, so projects
+// spread over the categories.
+func (g *generator) prompts(path string) []claude.HistoryEntry {
+	span := time.Duration(g.opts.Days) * 24 * time.Hour
+	last := g.opts.Now.Add(-time.Duration(g.rnd.Int64N(int64(span))))
+	first := last.Add(-time.Duration(g.rnd.Int64N(int64(span - g.opts.Now.Sub(last) + 1))))
+
+	entries := make([]claude.HistoryEntry, g.opts.Prompts)
+	for i := range entries {
+		t := last
+		if i < len(entries)-1 {
+			t = first.Add(time.Duration(g.rnd.Int64N(int64(last.Sub(first) + 1))))
+		}
+		entries[i] = claude.HistoryEntry{Display: g.sentence(4, 12), Timestamp: t.UnixMilli(), Project: path}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Timestamp < entries[j].Timestamp })
+	return entries
+}
+
+// sessions writes the project's sessions-index.json and session files and
+// returns the session IDs. Sessions start at evenly spaced prompts.
+func (g *generator) sessions(path string, prompts []claude.HistoryEntry) ([]string, error) {
+	if g.opts.Sessions == 0 {
+		return nil, nil
+	}
+	dir := filepath.Join(g.fx.ClaudeDir, "projects", strings.ReplaceAll(path, "/", "-"))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	idx := claude.SessionsIndex{Version: 1}
+	var ids []string
+	for s := range g.opts.Sessions {
+		start := prompts[s*len(prompts)/g.opts.Sessions]
+		created := time.UnixMilli(start.Timestamp).UTC()
+		id := g.uuid()
+		branch := "main"
+		if s%2 == 1 {
+			branch = "feature/" + words[g.rnd.IntN(len(words))]
+		}
+
+		msgs := g.messages(path, branch, start.Display, created)
+		file := filepath.Join(dir, id+".jsonl")
+		if err := writeJSONL(file, msgs); err != nil {
+			return nil, err
+		}
+
+		idx.Entries = append(idx.Entries, claude.SessionEntry{
+			SessionID:   id,
+			FullPath:    file,
+			FirstPrompt: start.Display,
+			Summary:     capitalize(g.sentence(2, 5)),
+			MsgCount:    len(msgs),
+			Created:     created.Format(time.RFC3339),
+			Modified:    created.Add(time.Duration(len(msgs)) * 30 * time.Second).Format(time.RFC3339),
+			GitBranch:   branch,
+			ProjectPath: path,
+		})
+		ids = append(ids, id)
+	}
+
+	b, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return ids, os.WriteFile(filepath.Join(dir, "sessions-index.json"), b, 0o644)
+}
+
+// message is a session file line. Assistant messages carry text and
+// tool_use blocks, tool results come back as user messages.
+type message struct {
+	Type      string `json:"type"`
+	Message   any    `json:"message"`
+	Timestamp string `json:"timestamp"`
+	CWD       string `json:"cwd"`
+	GitBranch string `json:"gitBranch"`
+}
+
+type block struct {
+	Type      string         `json:"type"`
+	Text      string         `json:"text,omitempty"`
+	ID        string         `json:"id,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Input     map[string]any `json:"input,omitempty"`
+	ToolUseID string         `json:"tool_use_id,omitempty"`
+	Content   string         `json:"content,omitempty"`
+}
+
+// messages generates a conversation of user prompts, assistant replies
+// with tool calls, and tool results. Some replies leave TODO markers and
+// unchecked checkboxes behind, for deep mode to find.
+func (g *generator) messages(path, branch, firstPrompt string, t time.Time) []message {
+	msgs := make([]message, 0, g.opts.Messages)
+	add := func(typ string, m any) {
+		msgs = append(msgs, message{Type: typ, Message: m, Timestamp: t.Format(time.RFC3339Nano), CWD: path, GitBranch: branch})
+		t = t.Add(30 * time.Second)
+	}
+
+	prompt := firstPrompt
+	for len(msgs) < g.opts.Messages {
+		add("user", map[string]string{"role": "user", "content": prompt})
+		prompt = g.sentence(4, 12)
+		if len(msgs) == g.opts.Messages {
+			break
+		}
+
+		text := g.sentence(10, 30) + "."
+		switch g.rnd.IntN(8) {
+		case 0:
+			text += "\n\nTODO: " + g.sentence(3, 8)
+		case 1:
+			text += "\n\n- [ ] " + g.sentence(3, 8)
+		}
+		toolID := "toolu_" + g.uuid()[:8]
+		file := words[g.rnd.IntN(len(words))] + ".go"
+		add("assistant", map[string]any{"role": "assistant", "content": []block{
+			{Type: "text", Text: text},
+			{Type: "tool_use", ID: toolID, Name: "Read", Input: map[string]any{"file_path": filepath.Join(path, file)}},
+		}})
+		if len(msgs) == g.opts.Messages {
+			break
+		}
+
+		add("user", map[string]any{"role": "user", "content": []block{
+			{Type: "tool_result", ToolUseID: toolID, Content: "package main\n\n// " + g.sentence(5, 15) + "\n"},
+		}})
+	}
+	return msgs
+}
+
+func (g *generator) sentence(minWords, maxWords int) string {
+	n := minWords + g.rnd.IntN(maxWords-minWords+1)
+	w := make([]string, n)
+	for i := range w {
+		w[i] = words[g.rnd.IntN(len(words))]
+	}
+	return strings.Join(w, " ")
+}
+
+func (g *generator) uuid() string {
+	return fmt.Sprintf("%08x-%04x-4%03x-8%03x-%012x",
+		g.rnd.Uint32(), g.rnd.IntN(1<<16), g.rnd.IntN(1<<12), g.rnd.IntN(1<<12), g.rnd.Uint64()&(1<<48-1))
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// createRepo creates a project directory in the given state.
+func createRepo(dir, state string) error {
+	if state == "missing" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	write := func(name, content string) error {
+		return os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+	}
+	if err := write("main.go", "package main\n"); err != nil || state == "none" {
+		return err
+	}
+
+	git := func(args ...string) error {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Squirrel", "-c", "user.email=squirrel@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, out)
+		}
+		return nil
+	}
+	steps := [][]string{{"init", "-q", "-b", "main"}, {"add", "."}, {"commit", "-q", "-m", "Initial commit"}}
+	if strings.HasPrefix(state, "feature") {
+		steps = append(steps, []string{"checkout", "-q", "-b", "feature/" + filepath.Base(dir)})
+	}
+	for _, args := range steps {
+		if err := git(args...); err != nil {
+			return err
+		}
+	}
+
+	if strings.HasSuffix(state, "dirty") {
+		if err := write("main.go", "package main\n\nfunc main() {}\n"); err != nil {
+			return err
+		}
+		return write("notes.txt", "work in progress\n")
+	}
+	return nil
+}
+
+func writeJSONL[T any](path string, lines []T) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, l := range lines {
+		if err := enc.Encode(l); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// words supplies project names, prompts and messages.
+var words = []string{
+	"acorn", "api", "auth", "billing", "cache", "cli", "config", "dashboard",
+	"deploy", "docs", "export", "feed", "forms", "gateway", "hazel", "import",
+	"index", "login", "metrics", "migrate", "nuts", "oak", "parser", "queue",
+	"refactor", "render", "search", "schema", "session", "sync", "tests", "theme",
+	"upload", "walnut", "webhook", "worker",
+}
@@ -0,0 +1,96 @@
+package fixture
+
+import (
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/internal/git"
+)
+
+func TestGenerate(t *testing.T) {
+	now := time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+	opts := Options{Projects: 4, Prompts: 20, Sessions: 2, Messages: 9, Days: 10, Seed: 7, Now: now}
+	fx, err := Generate(t.TempDir(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := claude.ParseHistory(filepath.Join(fx.ClaudeDir, "history.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 80 {
+		t.Fatalf("got %d history entries, want 80", len(entries))
+	}
+	for i, e := range entries {
+		if ts := time.UnixMilli(e.Timestamp); ts.Before(now.AddDate(0, 0, -10)) || ts.After(now) {
+			t.Fatalf("entry %d at %v is outside the range", i, ts)
+		}
+		if i > 0 && e.Timestamp < entries[i-1].Timestamp {
+			t.Fatal("history is not in chronological order")
+		}
+	}
+
+	projects := claude.AggregateRange(entries, now.AddDate(0, 0, -10), now.Add(time.Second))
+	if len(projects) != 4 {
+		t.Fatalf("got %d projects, want 4", len(projects))
+	}
+	claude.EnrichWithSessions(projects, filepath.Join(fx.ClaudeDir, "projects"))
+	for _, p := range projects {
+		if len(p.Sessions) != 2 || p.Sessions[0].MsgCount != 9 || p.LatestSummary == "" {
+			t.Errorf("%s: %d sessions, latest summary %q", p.ShortName, len(p.Sessions), p.LatestSummary)
+		}
+	}
+
+	msgs, err := claude.ParseSessionMessages(filepath.Join(fx.ClaudeDir, "projects", strings.ReplaceAll(fx.Projects[0].Path, "/", "-"), fx.Projects[0].Sessions[0]+".jsonl"), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, m := range msgs[:4] {
+		types = append(types, m.Type)
+	}
+	if want := []string{"user", "assistant", "user", "user"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("message types = %v, want %v", types, want)
+	}
+	if claude.ExtractText(msgs[1]) == "" {
+		t.Error("assistant message has no text")
+	}
+
+	// The same options generate the same data
+	again, err := Generate(t.TempDir(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again.Projects[1].Sessions, fx.Projects[1].Sessions) {
+		t.Error("generation is not deterministic")
+	}
+}
+
+func TestGenerateRepos(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	fx, err := Generate(t.TempDir(), Options{Projects: len(RepoStates), Prompts: 2, Repos: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range fx.Projects {
+		status, err := git.CheckStatus(p.Path)
+		if err != nil {
+			t.Fatalf("%s: %v", p.State, err)
+		}
+		isRepo := p.State != "none" && p.State != "missing"
+		dirty := p.State == "dirty" || p.State == "feature-dirty"
+		feature := p.State == "feature" || p.State == "feature-dirty"
+		if status.IsRepo != isRepo || status.IsDirty != dirty || status.IsFeatureBranch != feature {
+			t.Errorf("%s: repo %v, dirty %v, feature branch %v", p.State, status.IsRepo, status.IsDirty, status.IsFeatureBranch)
+		}
+	}
+}
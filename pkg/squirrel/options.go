@@ -0,0 +1,199 @@
+package squirrel
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+)
+
+// Option configures a Squirrel.
+type Option func(*options) error
+
+type options struct {
+	a          analyzer.Analyzer
+	cfg        *Config
+	configFile string
+	days       int
+	rangeSet   bool
+}
+
+// New returns a Squirrel configured by opts. Without options it reads
+// DefaultClaudeDir with an empty config, at medium depth, over the last 14
+// days.
+func New(opts ...Option) (*Squirrel, error) {
+	o := &options{days: 14}
+	o.a.Depth = string(Medium)
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.cfg == nil {
+		o.cfg = &Config{}
+		if o.configFile != "" {
+			cfg, err := LoadConfig(o.configFile)
+			if err != nil {
+				return nil, err
+			}
+			o.cfg = cfg
+		}
+	}
+	o.a.Config = o.cfg.config()
+	if len(o.a.Profiles) == 0 {
+		for _, d := range ClaudeDirs() {
+			o.a.Profiles = append(o.a.Profiles, analyzer.Profile{Name: d.Name, FS: os.DirFS(d.Dir)})
+		}
+	}
+	if !o.rangeSet {
+		now := time.Now()
+		if o.a.Now != nil {
+			now = o.a.Now()
+		}
+		o.a.Range = analyzer.Range{Since: now.AddDate(0, 0, -o.days), Until: now}
+	}
+
+	a := o.a
+	return &Squirrel{a: &a, cfg: o.cfg}, nil
+}
+
+// WithClaudeDirs reads the given Claude data directories, each "dir" or
+// "name=dir" as parsed by ClaudeDirs. Projects used in several directories
+// are labelled with their names.
+func WithClaudeDirs(specs ...string) Option {
+	return func(o *options) error {
+		for _, d := range ClaudeDirs(specs...) {
+			o.a.Profiles = append(o.a.Profiles, analyzer.Profile{Name: d.Name, FS: os.DirFS(d.Dir)})
+		}
+		return nil
+	}
+}
+
+// WithFS reads a Claude data directory from fsys, e.g. an embedded or
+// in-memory one. fsys holds history.jsonl and projects/.
+func WithFS(name string, fsys fs.FS) Option {
+	return func(o *options) error {
+		o.a.Profiles = append(o.a.Profiles, analyzer.Profile{Name: name, FS: fsys})
+		return nil
+	}
+}
+
+// WithConfig follows cfg. Acknowledging, tagging and the like through cfg
+// is seen by later analyses.
+func WithConfig(cfg *Config) Option {
+	return func(o *options) error {
+		o.cfg = cfg
+		return nil
+	}
+}
+
+// WithConfigFile loads the config from path; a missing file is an empty
+// config. WithConfig takes precedence.
+func WithConfigFile(path string) Option {
+	return func(o *options) error {
+		o.configFile = path
+		return nil
+	}
+}
+
+// WithClock sets the source of the current time, which acknowledgement
+// expiry and reminders are checked against. The config keeps its own clock
+// for the timestamps of changes made through it.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) error {
+		o.a.Now = now
+		return nil
+	}
+}
+
+// WithGit runs git through git instead of the git binary; nil means the
+// git binary.
+func WithGit(git GitRunner) Option {
+	return func(o *options) error {
+		o.a.Git = toGitRunner(git)
+		return nil
+	}
+}
+
+// WithDepth sets the analysis depth.
+func WithDepth(d Depth) Option {
+	return func(o *options) error {
+		if !slices.Contains([]Depth{Quick, Medium, Deep}, d) {
+			return fmt.Errorf("invalid depth %q (use quick, medium or deep)", d)
+		}
+		o.a.Depth = string(d)
+		return nil
+	}
+}
+
+// WithDays analyses the days days up to now.
+func WithDays(days int) Option {
+	return func(o *options) error {
+		if days < 1 {
+			return fmt.Errorf("days must be at least 1, got %d", days)
+		}
+		o.days, o.rangeSet = days, false
+		return nil
+	}
+}
+
+// WithRange analyses the range r; projects are judged as of r.Until.
+// ParseRange turns expressions like "last-week" into a Range.
+func WithRange(r Range) Option {
+	return func(o *options) error {
+		if r.Until.Before(r.Since) {
+			return fmt.Errorf("range starts %s, after it ends %s", r.Since.Format(time.DateTime), r.Until.Format(time.DateTime))
+		}
+		o.a.Range, o.rangeSet = analyzer.Range(r), true
+		return nil
+	}
+}
+
+// WithAllHosts adds projects that only exist on other machines, read from
+// the config's sync directory.
+func WithAllHosts(all bool) Option {
+	return func(o *options) error {
+		o.a.AllHosts = all
+		return nil
+	}
+}
+
+// WithIgnored fills Result.Ignored with the projects the ignore list hides.
+func WithIgnored(include bool) Option {
+	return func(o *options) error {
+		o.a.IncludeIgnored = include
+		return nil
+	}
+}
+
+// WithTags keeps only projects with any of tags.
+func WithTags(tags ...string) Option {
+	return func(o *options) error {
+		o.a.Tags = append(o.a.Tags, tags...)
+		return nil
+	}
+}
+
+// WithGroups keeps only projects in any of groups; an unknown group fails
+// the analysis.
+func WithGroups(groups ...string) Option {
+	return func(o *options) error {
+		o.a.Groups = append(o.a.Groups, groups...)
+		return nil
+	}
+}
+
+// ParseRange resolves --since and --until style expressions, a date
+// (2006-01-02) or one of RangeExpressions, into a Range. An empty since
+// starts days days before the end, an empty until ends at now.
+func ParseRange(since, until string, days int, now time.Time) (Range, error) {
+	r, err := analyzer.ParseRange(since, until, days, now)
+	return Range(r), err
+}
+
+// RangeExpressions lists the relative expressions ParseRange accepts.
+var RangeExpressions = analyzer.RangeExpressions
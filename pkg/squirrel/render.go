@@ -0,0 +1,315 @@
+package squirrel
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/i18n"
+	"github.com/dkd-dobberkau/squirrel/internal/output"
+)
+
+// Languages lists the supported output languages.
+func Languages() []string {
+	return i18n.Supported()
+}
+
+// DetectLanguage returns the language requested by LC_ALL, LC_MESSAGES or
+// LANG, falling back to English.
+func DetectLanguage() string {
+	return i18n.Detect()
+}
+
+// ColorModes lists the modes accepted by WithColor.
+var ColorModes = output.ColorModes
+
+// Renderer renders results as text for people: terminal output, Markdown,
+// HTML and templates, in its language, colour mode and width. The JSON,
+// CSV and NDJSON renderers are functions of their own since they do not
+// depend on these settings. The zero value renders English, coloured as the
+// terminal allows, without fitting lines. A Renderer is safe for concurrent
+// use, also alongside Renderers with other settings.
+type Renderer struct {
+	lang  string
+	color string
+	width int
+}
+
+// RenderOption configures a Renderer.
+type RenderOption func(*Renderer) error
+
+// NewRenderer returns a Renderer configured by opts.
+func NewRenderer(opts ...RenderOption) (*Renderer, error) {
+	rd := &Renderer{}
+	for _, opt := range opts {
+		if err := opt(rd); err != nil {
+			return nil, err
+		}
+	}
+	return rd, nil
+}
+
+// WithLanguage renders text in lang, one of Languages or a locale such as
+// "de_DE.UTF-8". The default is English.
+func WithLanguage(lang string) RenderOption {
+	return func(rd *Renderer) error {
+		tag := i18n.Normalize(lang)
+		if tag == "" {
+			return fmt.Errorf("unsupported language %q (use %s)", lang, strings.Join(Languages(), ", "))
+		}
+		rd.lang = tag
+		return nil
+	}
+}
+
+// WithColor selects whether terminal output is coloured: "auto" (the
+// default) follows the terminal and NO_COLOR, "always" and "never" force it.
+func WithColor(mode string) RenderOption {
+	return func(rd *Renderer) error {
+		if !slices.Contains(ColorModes, mode) {
+			return fmt.Errorf("invalid color mode %q (use %s)", mode, strings.Join(ColorModes, ", "))
+		}
+		rd.color = mode
+		return nil
+	}
+}
+
+// WithWidth fits terminal output into width columns; 0, the default, does
+// not fit it.
+func WithWidth(width int) RenderOption {
+	return func(rd *Renderer) error {
+		rd.width = max(width, 0)
+		return nil
+	}
+}
+
+// Language returns the tag of the language the renderer writes.
+func (rd *Renderer) Language() string {
+	return cmp.Or(rd.lang, i18n.Fallback)
+}
+
+// Message returns the catalogue message key in the renderer's language,
+// formatted with args, as the t template function does.
+func (rd *Renderer) Message(key string, args ...any) string {
+	return text(rd, func() string { return i18n.T(key, args...) })
+}
+
+// FormatDate formats t as a date in the renderer's language, e.g.
+// "Mar 1, 2026" or "01.03.2026".
+func (rd *Renderer) FormatDate(t time.Time) string {
+	return text(rd, func() string { return i18n.Date(t) })
+}
+
+// FormatDateTime formats t as date and time in the renderer's language.
+func (rd *Renderer) FormatDateTime(t time.Time) string {
+	return text(rd, func() string { return i18n.DateTime(t) })
+}
+
+// renderMu guards the language, colour profile and width that the
+// internal renderers share.
+var renderMu sync.Mutex
+
+// render runs fn with the renderer's settings in effect.
+func render[T any](rd *Renderer, fn func() (T, error)) (T, error) {
+	renderMu.Lock()
+	defer renderMu.Unlock()
+	// The settings were validated by the options
+	_ = i18n.Set(rd.Language())
+	_ = output.SetColor(cmp.Or(rd.color, "auto"))
+	output.SetWidth(rd.width)
+	return fn()
+}
+
+// text is render for renderers that cannot fail.
+func text(rd *Renderer, fn func() string) string {
+	s, _ := render(rd, func() (string, error) { return fn(), nil })
+	return s
+}
+
+// Fields lists the columns of RenderCSV and RenderNDJSON in their stable
+// order: new columns are only appended.
+var Fields = output.Fields
+
+// ValidateFields checks that fields are all Fields.
+func ValidateFields(fields []string) error {
+	_, err := output.ParseFields(fields)
+	return err
+}
+
+// RenderTerminal renders a result as styled terminal output, as shown by
+// squirrel status.
+func (rd *Renderer) RenderTerminal(r Result) string {
+	return text(rd, func() string { return output.RenderTerminal(toResult(r)) })
+}
+
+// RenderMarkdown renders a result as Markdown tables.
+func (rd *Renderer) RenderMarkdown(r Result) string {
+	return text(rd, func() string { return output.RenderMarkdown(toResult(r)) })
+}
+
+// RenderJSON renders a result as the status JSON document described by
+// squirrel schema status.
+func RenderJSON(r Result) (string, error) {
+	return output.RenderJSON(toResult(r))
+}
+
+// RenderCSV renders a result as one row per project with the given fields,
+// or all Fields if none are given, separated by sep.
+func RenderCSV(r Result, fields []string, sep rune) (string, error) {
+	cols, err := output.ParseFields(fields)
+	if err != nil {
+		return "", err
+	}
+	return output.RenderCSV(toResult(r), cols, sep)
+}
+
+// RenderNDJSON renders a result as one JSON object per project and line
+// with the given fields, or all Fields if none are given.
+func RenderNDJSON(r Result, fields []string) (string, error) {
+	cols, err := output.ParseFields(fields)
+	if err != nil {
+		return "", err
+	}
+	return output.RenderNDJSON(toResult(r), cols)
+}
+
+// RenderProject renders a project detail as terminal output.
+func (rd *Renderer) RenderProject(d ProjectDetail) string {
+	detail := toProjectDetail(d)
+	return text(rd, func() string { return output.RenderProjectDetail(detail.Project, detail.RecentPrompts) })
+}
+
+// RenderProjectMarkdown renders a project detail as Markdown.
+func (rd *Renderer) RenderProjectMarkdown(d ProjectDetail) string {
+	return text(rd, func() string { return output.RenderProjectDetailMarkdown(toProjectDetail(d)) })
+}
+
+// RenderProjectJSON renders a project detail as the project JSON document.
+func RenderProjectJSON(d ProjectDetail) (string, error) {
+	return output.RenderProjectDetailJSON(toProjectDetail(d))
+}
+
+// RenderProjectCSV renders a project detail as a RenderCSV row.
+func RenderProjectCSV(d ProjectDetail, fields []string, sep rune) (string, error) {
+	cols, err := output.ParseFields(fields)
+	if err != nil {
+		return "", err
+	}
+	return output.RenderProjectCSV(toProjectDetail(d), cols, sep)
+}
+
+// RenderProjectNDJSON renders a project detail as a RenderNDJSON line.
+func RenderProjectNDJSON(d ProjectDetail, fields []string) (string, error) {
+	cols, err := output.ParseFields(fields)
+	if err != nil {
+		return "", err
+	}
+	return output.RenderProjectNDJSON(toProjectDetail(d), cols)
+}
+
+// RenderTimeline renders timeline buckets made by "day", "week" or
+// "month" as terminal output.
+func (rd *Renderer) RenderTimeline(buckets []TimelineBucket, by string) string {
+	return text(rd, func() string { return output.RenderTimeline(convert(buckets, toTimelineBucket), by) })
+}
+
+// RenderTimelineMarkdown renders timeline buckets as Markdown.
+func (rd *Renderer) RenderTimelineMarkdown(buckets []TimelineBucket, by string) string {
+	return text(rd, func() string { return output.RenderTimelineMarkdown(convert(buckets, toTimelineBucket), by) })
+}
+
+// RenderTimelineJSON renders timeline buckets as the timeline JSON document.
+func RenderTimelineJSON(buckets []TimelineBucket, by string) (string, error) {
+	return output.RenderTimelineJSON(convert(buckets, toTimelineBucket), by)
+}
+
+// RenderStats renders stats as terminal output.
+func (rd *Renderer) RenderStats(st Stats) string {
+	return text(rd, func() string { return output.RenderStats(toStats(st)) })
+}
+
+// RenderStatsJSON renders stats as the stats JSON document.
+func RenderStatsJSON(st Stats) (string, error) {
+	return output.RenderStatsJSON(toStats(st))
+}
+
+// RenderHeatmap renders prompt counts per day, as returned by
+// DailyActivity, as a grid of weeks weeks ending with the day of end,
+// narrowed to the renderer's width. The title names the project, unless
+// project is empty.
+func (rd *Renderer) RenderHeatmap(counts map[string]int, end time.Time, weeks int, project string) string {
+	return text(rd, func() string {
+		title := i18n.T("heatmap.title")
+		if project != "" {
+			title += " - " + project
+		}
+		return output.RenderHeatmap(title, counts, end, weeks, rd.width)
+	})
+}
+
+// RenderHTML renders a self-contained HTML dashboard with activity heatmaps
+// drawn from entries up to now.
+func (rd *Renderer) RenderHTML(r Result, entries []HistoryEntry, now time.Time) (string, error) {
+	return render(rd, func() (string, error) {
+		return output.RenderHTML(toResult(r), convert(entries, toHistoryEntry), now)
+	})
+}
+
+// Schemas lists the JSON documents Schema describes: "status", "project",
+// "timeline" and "stats".
+func Schemas() []string {
+	return slices.Sorted(maps.Keys(output.Schemas))
+}
+
+// Schema returns the JSON Schema of the named document.
+func Schema(name string) (string, error) {
+	doc, ok := output.Schemas[name]
+	if !ok {
+		return "", fmt.Errorf("unknown schema %q (use %s)", name, strings.Join(Schemas(), ", "))
+	}
+	return output.JSONSchema(doc, "squirrel "+name+" output")
+}
+
+// BuiltinTemplates lists the names of the templates shipped with squirrel.
+var BuiltinTemplates = output.BuiltinTemplates
+
+// Template is a text/template for results and project details, as
+// documented for squirrel --template. Renderer.RenderTemplate and
+// Renderer.RenderProjectTemplate execute it.
+type Template struct {
+	t *output.Template
+}
+
+// LoadTemplate parses the template file at path.
+func LoadTemplate(path string) (*Template, error) {
+	t, err := output.LoadTemplate(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{t: t}, nil
+}
+
+// BuiltinTemplate returns the built-in template with the given name.
+func BuiltinTemplate(name string) (*Template, error) {
+	t, err := output.BuiltinTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{t: t}, nil
+}
+
+// RenderTemplate executes the template against a result. Relative times
+// count from now, normally the end of the analysed range.
+func (rd *Renderer) RenderTemplate(t *Template, r Result, now time.Time) (string, error) {
+	return render(rd, func() (string, error) { return t.t.RenderStatus(toResult(r), now) })
+}
+
+// RenderProjectTemplate executes the template against a project detail.
+// Relative times count from now, normally the end of the analysed range.
+func (rd *Renderer) RenderProjectTemplate(t *Template, d ProjectDetail, now time.Time) (string, error) {
+	return render(rd, func() (string, error) { return t.t.RenderProject(toProjectDetail(d), now) })
+}
@@ -0,0 +1,318 @@
+// Package server serves the analysis as a JSON API over HTTP. Results are
+// cached and refreshed in the background, so clients get answers without
+// the history being parsed for every request.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+// Server answers API requests from a cached analysis:
+//
+//	GET  /projects          status document, all categories
+//	GET  /projects/{id}     project document of an analysed project; id is a
+//	                        name, path or path suffix
+//	GET  /timeline?by=week  timeline document, by day (default), week or month
+//	GET  /stats             stats document
+//	POST /ack               acknowledge a project, see AckRequest
+//
+// It has no authentication. To keep web pages the user visits out, it only
+// answers requests addressed to Addr or localhost, and acknowledges only
+// JSON requests that come from no other origin.
+type Server struct {
+	// Addr is the address the server listens on, as "host:port".
+	Addr string
+	// Load returns a Squirrel for a fresh analysis. It is called on every
+	// refresh, so it should read the config anew and resolve relative
+	// ranges against the current time.
+	Load func() (*squirrel.Squirrel, error)
+	// Ack acknowledges the project matching query until expiresAt (nil is
+	// permanent), as a snooze when wakeOn is set, and returns the project.
+	// Unknown projects are reported with squirrel.ErrNotFound.
+	Ack func(query string, expiresAt *time.Time, wakeOn []string) (squirrel.Project, error)
+	// Refresh is the interval of background refreshes; 0 means one minute.
+	Refresh time.Duration
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+	// Logf reports failed refreshes; nil means log.Printf.
+	Logf func(format string, args ...any)
+
+	refreshMu sync.Mutex // serializes refreshes
+	mu        sync.RWMutex
+	snap      *snapshot
+}
+
+// snapshot is the cached result of one analysis.
+type snapshot struct {
+	*squirrel.Snapshot
+	stats squirrel.Stats
+	at    time.Time
+}
+
+// AckRequest is the body of POST /ack.
+type AckRequest struct {
+	Project string `json:"project"`
+	// For is a duration like "7d", "2w" or "3m"; empty is permanent.
+	For string `json:"for,omitempty"`
+	// WakeOn ends the acknowledgement early on events: prompts, dirty,
+	// remote, branch.
+	WakeOn []string `json:"wakeOn,omitempty"`
+}
+
+// AckResponse is the answer to POST /ack.
+type AckResponse struct {
+	Path      string     `json:"path"`
+	ShortName string     `json:"shortName"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	WakeOn    []string   `json:"wakeOn,omitempty"`
+}
+
+// Run analyses once and then refreshes the cache in the background until
+// ctx is done. It fails if the first analysis fails; later failures are
+// logged and keep the previous result.
+func (srv *Server) Run(ctx context.Context) error {
+	if err := srv.refresh(); err != nil {
+		return err
+	}
+
+	interval := srv.Refresh
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := srv.refresh(); err != nil {
+					srv.logf("refreshing analysis: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Handler returns the API's HTTP handler. Run must have succeeded before
+// it serves requests.
+func (srv *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects", srv.projects)
+	mux.HandleFunc("GET /projects/{id...}", srv.project)
+	mux.HandleFunc("GET /timeline", srv.timeline)
+	mux.HandleFunc("GET /stats", srv.statistics)
+	mux.HandleFunc("POST /ack", srv.ack)
+	return srv.checkHost(mux)
+}
+
+// checkHost rejects requests whose Host header names neither Addr nor a
+// loopback name with its port, as a DNS rebinding page's requests do.
+func (srv *Server) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !srv.isLocal(r.Host) {
+			writeJSON(w, http.StatusForbidden, errorBody(fmt.Errorf("host %q is not served", r.Host)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLocal reports whether host, a Host header or an origin's host, names
+// this server.
+func (srv *Server) isLocal(host string) bool {
+	if strings.EqualFold(host, srv.Addr) {
+		return true
+	}
+	_, port, err := net.SplitHostPort(srv.Addr)
+	if err != nil {
+		return false
+	}
+	name, hostPort, err := net.SplitHostPort(host)
+	if err != nil || hostPort != port {
+		return false
+	}
+	if strings.EqualFold(name, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(name)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (srv *Server) refresh() error {
+	srv.refreshMu.Lock()
+	defer srv.refreshMu.Unlock()
+
+	s, err := srv.Load()
+	if err != nil {
+		return err
+	}
+	snap, err := s.Snapshot()
+	if err != nil {
+		return err
+	}
+	stats := snap.Stats()
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.snap = &snapshot{Snapshot: snap, stats: stats, at: srv.now()}
+	return nil
+}
+
+// current returns the cached analysis and sets Last-Modified to its time.
+func (srv *Server) current(w http.ResponseWriter) *snapshot {
+	srv.mu.RLock()
+	defer srv.mu.RUnlock()
+	w.Header().Set("Last-Modified", srv.snap.at.UTC().Format(http.TimeFormat))
+	return srv.snap
+}
+
+func (srv *Server) projects(w http.ResponseWriter, r *http.Request) {
+	doc, err := squirrel.RenderJSON(srv.current(w).Result())
+	writeDocument(w, doc, err)
+}
+
+// project looks the project up among the cached ones. Projects inactive in
+// the range are not found, so no request costs a fresh analysis.
+func (srv *Server) project(w http.ResponseWriter, r *http.Request) {
+	detail, err := srv.current(w).Project(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	doc, err := squirrel.RenderProjectJSON(detail)
+	writeDocument(w, doc, err)
+}
+
+func (srv *Server) timeline(w http.ResponseWriter, r *http.Request) {
+	by := r.URL.Query().Get("by")
+	if by == "" {
+		by = "day"
+	}
+	buckets, err := srv.current(w).Timeline(by)
+	if err != nil { // an invalid period
+		writeJSON(w, http.StatusBadRequest, errorBody(err))
+		return
+	}
+	doc, err := squirrel.RenderTimelineJSON(buckets, by)
+	writeDocument(w, doc, err)
+}
+
+func (srv *Server) statistics(w http.ResponseWriter, r *http.Request) {
+	doc, err := squirrel.RenderStatsJSON(srv.current(w).stats)
+	writeDocument(w, doc, err)
+}
+
+// ack acknowledges a project and refreshes the cache, so the next request
+// sees the change. Requiring a JSON body and no foreign Origin keeps other
+// sites from posting forms or "simple" requests to it.
+func (srv *Server) ack(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || !srv.isLocal(u.Host) {
+			writeJSON(w, http.StatusForbidden, errorBody(fmt.Errorf("cross-origin request from %q", origin)))
+			return
+		}
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		writeJSON(w, http.StatusUnsupportedMediaType, errorBody(errors.New("content type must be application/json")))
+		return
+	}
+
+	var req AckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody(fmt.Errorf("invalid request body: %w", err)))
+		return
+	}
+	if req.Project == "" {
+		writeJSON(w, http.StatusBadRequest, errorBody(errors.New("project is required")))
+		return
+	}
+	var expiresAt *time.Time
+	if req.For != "" {
+		d, err := squirrel.ParseDuration(req.For)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorBody(err))
+			return
+		}
+		t := srv.now().Add(d)
+		expiresAt = &t
+	}
+	if err := squirrel.ValidateWakeOn(req.WakeOn); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody(err))
+		return
+	}
+
+	p, err := srv.Ack(req.Project, expiresAt, req.WakeOn)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := srv.refresh(); err != nil {
+		srv.logf("refreshing analysis: %v", err)
+	}
+	writeJSON(w, http.StatusOK, AckResponse{Path: p.Path, ShortName: p.ShortName, ExpiresAt: expiresAt, WakeOn: req.WakeOn})
+}
+
+func (srv *Server) now() time.Time {
+	if srv.Now != nil {
+		return srv.Now()
+	}
+	return time.Now()
+}
+
+func (srv *Server) logf(format string, args ...any) {
+	if srv.Logf != nil {
+		srv.Logf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// writeDocument answers with a rendered JSON document, or with 500 if it
+// could not be rendered.
+func writeDocument(w http.ResponseWriter, doc string, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, doc)
+}
+
+// writeError answers with 404 for unknown projects and 500 otherwise.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, squirrel.ErrNotFound) {
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, errorBody(err))
+}
+
+func errorBody(err error) map[string]string {
+	return map[string]string{"error": err.Error()}
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/output"
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var now = time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+
+// newServer returns a running server over prompts for /src/app yesterday,
+// /src/old ten days ago and /src/ancient before the range, and counts its
+// loads.
+func newServer(t *testing.T, refresh time.Duration) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	prompt := func(project string, daysAgo int) string {
+		return fmt.Sprintf(`{"display":"work","timestamp":%d,"project":%q}`+"\n", now.AddDate(0, 0, -daysAgo).UnixMilli(), project)
+	}
+	fsys := fstest.MapFS{
+		"history.jsonl":                         {Data: []byte(prompt("/src/ancient", 60) + prompt("/src/old", 10) + prompt("/src/app", 1))},
+		"projects/-src-app/sessions-index.json": {Data: []byte(`{"version":1,"entries":[{"sessionId":"s1","summary":"Login","messageCount":8,"created":"2026-03-15T10:00:00Z","modified":"2026-03-15T11:00:00Z"}]}`)},
+	}
+	git := func(path string, args ...string) (string, error) {
+		return "", errors.New("not a git repository")
+	}
+
+	cfg := &squirrel.Config{}
+	load := func() (*squirrel.Squirrel, error) {
+		return squirrel.New(
+			squirrel.WithFS("work", fsys),
+			squirrel.WithConfig(cfg),
+			squirrel.WithClock(func() time.Time { return now }),
+			squirrel.WithGit(git),
+			squirrel.WithDays(30),
+		)
+	}
+
+	var loads atomic.Int32
+	srv := &Server{
+		Load: func() (*squirrel.Squirrel, error) {
+			loads.Add(1)
+			return load()
+		},
+		Ack: func(query string, expiresAt *time.Time, wakeOn []string) (squirrel.Project, error) {
+			s, err := load()
+			if err != nil {
+				return squirrel.Project{}, err
+			}
+			p, err := s.Resolve(query)
+			if err == nil {
+				cfg.Ack(p.Path, expiresAt)
+			}
+			return p, err
+		},
+		Refresh: refresh,
+		Now:     func() time.Time { return now },
+		Logf:    t.Logf,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := srv.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewUnstartedServer(srv.Handler())
+	srv.Addr = ts.Listener.Addr().String()
+	ts.Start()
+	t.Cleanup(ts.Close)
+	return ts, &loads
+}
+
+func get[T any](t *testing.T, url string, wantStatus int) T {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("GET %s: status %d, want %d", url, resp.StatusCode, wantStatus)
+	}
+	var v T
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	return v
+}
+
+func TestEndpoints(t *testing.T) {
+	ts, _ := newServer(t, time.Hour)
+
+	status := get[output.StatusDocument](t, ts.URL+"/projects", http.StatusOK)
+	if len(status.RecentActivity) != 1 || status.RecentActivity[0].ShortName != "app" || len(status.Sleeping) != 1 {
+		t.Errorf("projects: recent %+v, %d sleeping", status.RecentActivity, len(status.Sleeping))
+	}
+
+	detail := get[output.ProjectDocument](t, ts.URL+"/projects/src/app", http.StatusOK)
+	if detail.Project.Path != "/src/app" || detail.Project.LatestSummary != "Login" || len(detail.RecentPrompts) != 1 {
+		t.Errorf("project: %+v", detail)
+	}
+	if body := get[map[string]string](t, ts.URL+"/projects/nope", http.StatusNotFound); !strings.Contains(body["error"], "not found") {
+		t.Errorf("unknown project: %v", body)
+	}
+	// Projects outside the range are not looked up afresh
+	get[map[string]string](t, ts.URL+"/projects/ancient", http.StatusNotFound)
+
+	timeline := get[output.TimelineDocument](t, ts.URL+"/timeline?by=week", http.StatusOK)
+	if timeline.By != "week" || len(timeline.Buckets) != 2 {
+		t.Errorf("timeline: by %q, %d buckets", timeline.By, len(timeline.Buckets))
+	}
+	get[map[string]string](t, ts.URL+"/timeline?by=year", http.StatusBadRequest)
+
+	stats := get[output.StatsDocument](t, ts.URL+"/stats", http.StatusOK)
+	if stats.Prompts != 2 || stats.Sessions != 1 {
+		t.Errorf("stats: %d prompts, %d sessions", stats.Prompts, stats.Sessions)
+	}
+}
+
+func TestAck(t *testing.T) {
+	ts, _ := newServer(t, time.Hour)
+	post := func(body string) *http.Response {
+		t.Helper()
+		resp, err := http.Post(ts.URL+"/ack", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	for body, want := range map[string]int{
+		`{"project":"app","for":"forever"}`:   http.StatusBadRequest,
+		`{"project":"app","wakeOn":["rain"]}`: http.StatusBadRequest,
+		`{"for":"1w"}`:                        http.StatusBadRequest,
+		`{"project":"nope"}`:                  http.StatusNotFound,
+		`{"project":"app","for":"1w"}`:        http.StatusOK,
+	} {
+		if resp := post(body); resp.StatusCode != want {
+			t.Errorf("POST /ack %s: status %d, want %d", body, resp.StatusCode, want)
+		}
+	}
+
+	// The acknowledgement shows without waiting for a refresh
+	status := get[output.StatusDocument](t, ts.URL+"/projects", http.StatusOK)
+	if len(status.Acknowledged) != 1 || status.Acknowledged[0].ShortName != "app" {
+		t.Errorf("acknowledged = %+v", status.Acknowledged)
+	}
+}
+
+func TestForeignRequests(t *testing.T) {
+	ts, _ := newServer(t, time.Hour)
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(ts.URL, "http://"))
+	do := func(method, path, host string, header map[string]string) int {
+		t.Helper()
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(`{"project":"app"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = host
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	jsonType := map[string]string{"Content-Type": "application/json"}
+	for _, tc := range []struct {
+		name         string
+		method, host string
+		header       map[string]string
+		want         int
+	}{
+		{"localhost", "GET", "localhost:" + port, nil, http.StatusOK},
+		{"rebound host", "GET", "evil.example:" + port, nil, http.StatusForbidden},
+		{"other port", "GET", "localhost:1", nil, http.StatusForbidden},
+		{"text body", "POST", "127.0.0.1:" + port, map[string]string{"Content-Type": "text/plain"}, http.StatusUnsupportedMediaType},
+		{"no content type", "POST", "127.0.0.1:" + port, nil, http.StatusUnsupportedMediaType},
+		{"foreign origin", "POST", "127.0.0.1:" + port, map[string]string{"Content-Type": "application/json", "Origin": "https://evil.example"}, http.StatusForbidden},
+		{"null origin", "POST", "127.0.0.1:" + port, map[string]string{"Content-Type": "application/json", "Origin": "null"}, http.StatusForbidden},
+		{"own origin", "POST", "127.0.0.1:" + port, map[string]string{"Content-Type": "application/json; charset=utf-8", "Origin": "http://localhost:" + port}, http.StatusOK},
+		{"no origin", "POST", "127.0.0.1:" + port, jsonType, http.StatusOK},
+	} {
+		path := "/projects"
+		if tc.method == "POST" {
+			path = "/ack"
+		}
+		if got := do(tc.method, path, tc.host, tc.header); got != tc.want {
+			t.Errorf("%s: status %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestBackgroundRefresh(t *testing.T) {
+	_, loads := newServer(t, 10*time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for loads.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d loads, want at least 3", loads.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
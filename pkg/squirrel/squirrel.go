@@ -0,0 +1,399 @@
+// Package squirrel finds forgotten Claude Code projects. It reads Claude's
+// prompt history and sessions, adds git state, and sorts the projects into
+// open work, recent activity and sleeping ones, honouring the
+// acknowledgements, notes, tags and ignore list of a squirrel config.
+//
+// A Squirrel is set up with functional options and then queried:
+//
+//	s, err := squirrel.New(
+//		squirrel.WithClaudeDirs("~/.claude"),
+//		squirrel.WithConfigFile(squirrel.DefaultConfigPath()),
+//		squirrel.WithDays(30),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	result, err := s.Analyze()
+//	if err != nil {
+//		return err
+//	}
+//	var rd squirrel.Renderer
+//	fmt.Print(rd.RenderTerminal(result))
+//
+// A Renderer renders results as terminal output, Markdown, HTML or
+// templates in its language, colour mode and width; RenderJSON, RenderCSV
+// and the like need no settings.
+//
+// The squirrel command is built on this package.
+package squirrel
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/internal/syncstore"
+)
+
+// Depth selects how much work an analysis does.
+type Depth string
+
+const (
+	// Quick reads the history and sessions only.
+	Quick Depth = "quick"
+	// Medium adds git status; it is the default.
+	Medium Depth = "medium"
+	// Deep adds TODOs extracted from session files.
+	Deep Depth = "deep"
+)
+
+// ErrNotFound is returned, wrapped, when no project matches a query.
+var ErrNotFound = analyzer.ErrNotFound
+
+// TimelinePeriods lists the bucket sizes accepted by Timeline.
+var TimelinePeriods = analyzer.TimelinePeriods
+
+// Squirrel analyses Claude data directories. Create one with New; it is
+// not safe for concurrent use while its config is modified.
+type Squirrel struct {
+	a   *analyzer.Analyzer
+	cfg *Config
+}
+
+// Config returns the config the analysis follows.
+func (s *Squirrel) Config() *Config {
+	return s.cfg
+}
+
+// Range returns the time range analysed.
+func (s *Squirrel) Range() Range {
+	return Range(s.a.Range)
+}
+
+// History returns the prompts of all Claude data directories.
+func (s *Squirrel) History() ([]HistoryEntry, error) {
+	entries, _, err := s.a.History()
+	return convert(entries, fromHistoryEntry), err
+}
+
+// Analyze analyses the projects active in the range and sorts them into
+// categories.
+func (s *Squirrel) Analyze() (Result, error) {
+	result, err := s.a.Run()
+	return fromResult(result), err
+}
+
+// Projects returns the projects active in the range with their sessions
+// and, depending on the depth, git status, leaving out ignored ones. They
+// are not annotated or categorized.
+func (s *Squirrel) Projects() ([]Project, error) {
+	projects, err := s.projects()
+	return convert(projects, fromProject), err
+}
+
+func (s *Squirrel) projects() ([]claude.ProjectInfo, error) {
+	projects, err := s.a.Projects()
+	if err != nil {
+		return nil, err
+	}
+	projects, _ = s.a.SplitIgnored(projects)
+	return projects, nil
+}
+
+// Ignored returns the projects active in the range that the ignore list
+// hides, with IgnoredBy set to the matching entry.
+func (s *Squirrel) Ignored() ([]Project, error) {
+	entries, _, err := s.a.History()
+	if err != nil {
+		return nil, err
+	}
+	_, ignored := s.a.SplitIgnored(s.a.Aggregate(entries, s.a.Range))
+	return convert(ignored, fromProject), nil
+}
+
+// Resolve finds the project matching query, a path, name or path suffix,
+// among the projects active in the year up to the end of the range.
+func (s *Squirrel) Resolve(query string) (Project, error) {
+	project, err := s.a.Resolve(query)
+	return fromProject(project), err
+}
+
+// Project returns the detail of the project matching query, looked up in
+// the year up to the end of the range: the enriched and annotated project
+// with its ten latest prompts and whether it is acknowledged.
+func (s *Squirrel) Project(query string) (ProjectDetail, error) {
+	entries, owners, err := s.a.History()
+	if err != nil {
+		return ProjectDetail{}, err
+	}
+
+	projects := s.a.Aggregate(entries, s.a.Lookback(365))
+	s.a.Enrich(projects, owners)
+	for i := range projects {
+		projects[i].Score = analyzer.Score(projects[i])
+	}
+
+	project, ok := claude.FindProject(projects, query)
+	if !ok {
+		return ProjectDetail{}, fmt.Errorf("project %q %w", query, ErrNotFound)
+	}
+	s.a.Annotate(&project)
+	if s.a.Depth == string(Deep) {
+		s.a.EnrichWithTodos(&project)
+	}
+	ack := s.a.MatchAck(project)
+	project.AckedBy, project.WokenBy = ack.Rule, ack.WokenBy
+
+	return ProjectDetail{
+		Project:       fromProject(project),
+		RecentPrompts: convert(claude.PromptsForPaths(entries, project.Paths(), 10), fromHistoryEntry),
+		Acknowledged:  ack.Acknowledged,
+	}, nil
+}
+
+// Timeline buckets the prompts in the range by "day", "week" or "month",
+// newest first.
+func (s *Squirrel) Timeline(by string) ([]TimelineBucket, error) {
+	snap, err := s.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snap.Timeline(by)
+}
+
+// Stats computes productivity metrics over the range.
+func (s *Squirrel) Stats() (Stats, error) {
+	snap, err := s.Snapshot()
+	if err != nil {
+		return Stats{}, err
+	}
+	return snap.Stats(), nil
+}
+
+// DailyActivity counts prompts per day ("2006-01-02") over all history. With
+// a query it counts the prompts of the matching project and returns it,
+// otherwise those of every project not on the ignore list.
+func (s *Squirrel) DailyActivity(query string) (map[string]int, Project, error) {
+	entries, _, err := s.a.History()
+	if err != nil {
+		return nil, Project{}, err
+	}
+
+	projects := s.a.Aggregate(entries, s.a.Lookback(36500))
+	if query != "" {
+		p, ok := claude.FindProject(projects, query)
+		if !ok {
+			return nil, Project{}, fmt.Errorf("project %q %w", query, ErrNotFound)
+		}
+		return claude.DailyActivity(entries, p.Paths()), fromProject(p), nil
+	}
+
+	kept, _ := s.a.SplitIgnored(projects)
+	var paths []string
+	for _, p := range kept {
+		paths = append(paths, p.Paths()...)
+	}
+	return claude.DailyActivity(entries, paths), Project{}, nil
+}
+
+// MatchAck checks the project against the config's acknowledgements,
+// their wake-up conditions and ack rules.
+func (s *Squirrel) MatchAck(p Project) AckMatch {
+	return AckMatch(s.a.MatchAck(toProject(p)))
+}
+
+// AckState captures the project state a snooze wakes up from, for
+// Config.Snooze.
+func (s *Squirrel) AckState(p Project) AckSnapshot {
+	return fromAckSnapshot(s.a.AckState(toProject(p)))
+}
+
+// Snapshot analyses once and keeps the result, so that the status,
+// projects, timeline and stats of the same analysis can be queried without
+// reading the history again.
+func (s *Squirrel) Snapshot() (*Snapshot, error) {
+	result, err := s.a.Run()
+	if err != nil {
+		return nil, err
+	}
+	entries, _, err := s.a.History()
+	if err != nil {
+		return nil, err
+	}
+	projects := append(slices.Clone(result.OpenWork), result.RecentActivity...)
+	return &Snapshot{
+		r:        s.a.Range,
+		result:   result,
+		entries:  entries,
+		projects: append(projects, result.Sleeping...),
+	}, nil
+}
+
+// Snapshot is the result of one analysis. It is safe for concurrent use.
+type Snapshot struct {
+	r        analyzer.Range
+	result   analyzer.CategorizedProjects
+	entries  []claude.HistoryEntry
+	projects []claude.ProjectInfo // all categories but the acknowledged
+}
+
+// Range returns the time range analysed.
+func (sn *Snapshot) Range() Range {
+	return Range(sn.r)
+}
+
+// Result returns the analysed projects by category.
+func (sn *Snapshot) Result() Result {
+	return fromResult(sn.result)
+}
+
+// Project returns the detail of the analysed project matching query.
+// Unlike Squirrel.Project it only knows the projects active in the range.
+func (sn *Snapshot) Project(query string) (ProjectDetail, error) {
+	p, ok := claude.FindProject(sn.all(), query)
+	if !ok {
+		return ProjectDetail{}, fmt.Errorf("project %q %w", query, ErrNotFound)
+	}
+	return ProjectDetail{
+		Project:       fromProject(p),
+		RecentPrompts: convert(claude.PromptsForPaths(sn.entries, p.Paths(), 10), fromHistoryEntry),
+		Acknowledged:  slices.ContainsFunc(sn.result.Acknowledged, func(q claude.ProjectInfo) bool { return q.Path == p.Path }),
+	}, nil
+}
+
+// Timeline buckets the prompts in the range by "day", "week" or "month",
+// newest first.
+func (sn *Snapshot) Timeline(by string) ([]TimelineBucket, error) {
+	buckets, err := analyzer.Timeline(sn.entries, sn.all(), by, sn.r.Since, sn.r.Until)
+	return convert(buckets, fromTimelineBucket), err
+}
+
+// Stats computes productivity metrics over the range.
+func (sn *Snapshot) Stats() Stats {
+	return fromStats(analyzer.ComputeStats(sn.entries, sn.all(), sn.r.Since, sn.r.Until))
+}
+
+// all returns every project that is not ignored: acknowledging a project
+// hides it from the status, not its activity.
+func (sn *Snapshot) all() []claude.ProjectInfo {
+	return append(slices.Clone(sn.projects), sn.result.Acknowledged...)
+}
+
+func (s *Squirrel) now() time.Time {
+	if s.a.Now != nil {
+		return s.a.Now()
+	}
+	return time.Now()
+}
+
+// SyncResult reports what Sync exchanged.
+type SyncResult struct {
+	// Host is this machine's name in the sync directory.
+	Host string
+	// Hosts counts the other machines found.
+	Hosts int
+	// Imported counts the acknowledgement and note changes taken over.
+	Imported int
+	// Exported counts the projects written; File is where.
+	Exported int
+	File     string
+}
+
+// Sync shares state through the config's sync directory: it merges the
+// acknowledgements and notes other machines wrote there into the config,
+// then writes this machine's along with its projects, which WithAllHosts
+// shows elsewhere. The config is changed in memory only; sync within
+// UpdateConfig to keep the imported changes.
+func (s *Squirrel) Sync() (SyncResult, error) {
+	cfg := s.a.Config
+	if cfg.SyncDir == "" {
+		return SyncResult{}, errors.New("no sync directory configured")
+	}
+	snapshots, err := syncstore.ReadAll(cfg.SyncDir)
+	if err != nil {
+		return SyncResult{}, fmt.Errorf("reading sync directory: %w", err)
+	}
+	res := SyncResult{Host: syncstore.Hostname(cfg)}
+	res.Imported = syncstore.Import(cfg, snapshots, res.Host)
+	for _, snap := range snapshots {
+		if snap.Host != res.Host {
+			res.Hosts++
+		}
+	}
+
+	projects, err := s.projects()
+	if err != nil {
+		return SyncResult{}, err
+	}
+	err = syncstore.Write(cfg.SyncDir, syncstore.Snapshot{
+		Host:         res.Host,
+		ExportedAt:   s.now(),
+		Acknowledged: cfg.Acknowledged,
+		Unacked:      cfg.Unacked,
+		Notes:        cfg.Notes,
+		Projects:     projects,
+		Identities:   s.a.GitIdentities(projects),
+	})
+	if err != nil {
+		return SyncResult{}, err
+	}
+	res.Exported = len(projects)
+	res.File = filepath.Join(cfg.SyncDir, syncstore.FileName(res.Host))
+	return res, nil
+}
+
+// ClaudeDir is a Claude data directory, holding history.jsonl and
+// projects/, under a name that labels its projects.
+type ClaudeDir struct {
+	Name string
+	Dir  string
+}
+
+// DefaultClaudeDir returns $CLAUDE_CONFIG_DIR, else ~/.claude.
+func DefaultClaudeDir() string {
+	if env := os.Getenv("CLAUDE_CONFIG_DIR"); env != "" {
+		return env
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".claude")
+}
+
+// ClaudeDirs parses directory specs, each "dir" or "name=dir"; no specs
+// mean DefaultClaudeDir. Names default to the directory's base name without
+// a leading dot and are made unique; a leading ~ is expanded.
+func ClaudeDirs(specs ...string) []ClaudeDir {
+	if len(specs) == 0 {
+		specs = []string{DefaultClaudeDir()}
+	}
+
+	var out []ClaudeDir
+	for _, spec := range specs {
+		name, dir, ok := strings.Cut(spec, "=")
+		if !ok {
+			dir = spec
+			name = strings.TrimPrefix(filepath.Base(filepath.Clean(dir)), ".")
+		}
+		dir = expandTilde(dir)
+		// Keep labels unique when two directories share a base name
+		unique := name
+		for i := 2; slices.ContainsFunc(out, func(d ClaudeDir) bool { return d.Name == unique }); i++ {
+			unique = fmt.Sprintf("%s%d", name, i)
+		}
+		out = append(out, ClaudeDir{Name: unique, Dir: dir})
+	}
+	return out
+}
+
+func expandTilde(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+	return path
+}
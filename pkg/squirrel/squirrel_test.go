@@ -0,0 +1,291 @@
+package squirrel_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/pkg/squirrel"
+)
+
+var now = time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+
+// claudeDir is a Claude data directory with prompts for /src/app yesterday
+// and /src/old ten days ago.
+func claudeDir() fstest.MapFS {
+	prompt := func(project, text string, daysAgo int) string {
+		return fmt.Sprintf(`{"display":%q,"timestamp":%d,"project":%q}`+"\n", text, now.AddDate(0, 0, -daysAgo).UnixMilli(), project)
+	}
+	return fstest.MapFS{
+		"history.jsonl":                         {Data: []byte(prompt("/src/old", "write docs", 10) + prompt("/src/app", "add login", 1))},
+		"projects/-src-app/sessions-index.json": {Data: []byte(`{"version":1,"entries":[{"sessionId":"s1","summary":"Login","messageCount":12,"created":"2026-03-15T10:00:00Z","modified":"2026-03-15T11:00:00Z"}]}`)},
+		"projects/-src-app/s1.jsonl":            {Data: []byte(`{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"TODO: rate limiting"}]}}` + "\n")},
+	}
+}
+
+// git reports /src/app as dirty on main and every other path as no
+// repository.
+func git(path string, args ...string) (string, error) {
+	if path != "/src/app" {
+		return "", errors.New("not a git repository")
+	}
+	switch strings.Join(args, " ") {
+	case "rev-parse --abbrev-ref HEAD":
+		return "main\n", nil
+	case "status --porcelain":
+		return " M login.go\n", nil
+	}
+	return "", nil
+}
+
+func newSquirrel(t *testing.T, opts ...squirrel.Option) *squirrel.Squirrel {
+	t.Helper()
+	s, err := squirrel.New(append([]squirrel.Option{
+		squirrel.WithFS("work", claudeDir()),
+		squirrel.WithClock(func() time.Time { return now }),
+		squirrel.WithGit(git),
+		squirrel.WithDays(30),
+	}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestAnalyze(t *testing.T) {
+	s := newSquirrel(t)
+	result, err := s.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.OpenWork) != 1 || result.OpenWork[0].Path != "/src/app" || !result.OpenWork[0].GitDirty {
+		t.Errorf("openWork = %+v, want dirty /src/app", result.OpenWork)
+	}
+	if len(result.Sleeping) != 1 || result.Sleeping[0].Path != "/src/old" {
+		t.Errorf("sleeping = %+v, want /src/old", result.Sleeping)
+	}
+
+	// Acknowledging through the config shows in the next analysis
+	s.Config().Ack("/src/app", nil)
+	if result, err = s.Analyze(); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Acknowledged) != 1 || len(result.OpenWork) != 0 {
+		t.Errorf("after ack: %d acknowledged, %d open", len(result.Acknowledged), len(result.OpenWork))
+	}
+}
+
+func TestProject(t *testing.T) {
+	detail, err := newSquirrel(t, squirrel.WithDepth(squirrel.Deep)).Project("app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := detail.Project
+	if p.LatestSummary != "Login" || len(p.Todos) != 1 || p.Todos[0].Text != "rate limiting" {
+		t.Errorf("project = summary %q, todos %+v", p.LatestSummary, p.Todos)
+	}
+	if len(detail.RecentPrompts) != 1 || detail.RecentPrompts[0].Display != "add login" {
+		t.Errorf("recentPrompts = %+v", detail.RecentPrompts)
+	}
+
+	if _, err := newSquirrel(t).Project("nope"); err == nil {
+		t.Error("unknown project: no error")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	s := newSquirrel(t, squirrel.WithDays(7))
+	snap, err := s.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := snap.Result(); len(r.OpenWork) != 1 || len(r.Sleeping) != 0 {
+		t.Errorf("result = %d open, %d sleeping; want /src/app only", len(r.OpenWork), len(r.Sleeping))
+	}
+	detail, err := snap.Project("app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(detail.Project.Sessions) != 1 || detail.Project.Sessions[0].Modified != "2026-03-15T11:00:00Z" {
+		t.Errorf("sessions = %+v", detail.Project.Sessions)
+	}
+	// /src/old was last active before the range, unlike with Squirrel.Project
+	if _, err := snap.Project("old"); !errors.Is(err, squirrel.ErrNotFound) {
+		t.Errorf("inactive project: err = %v, want ErrNotFound", err)
+	}
+	if st := snap.Stats(); st.Prompts != 1 {
+		t.Errorf("stats = %d prompts, want 1", st.Prompts)
+	}
+}
+
+func TestClockLeavesConfigAlone(t *testing.T) {
+	cfg := &squirrel.Config{}
+	expires := now.AddDate(0, 0, 1)
+	cfg.Ack("/src/app", &expires)
+
+	// The ack has long expired, but not as of the Squirrel's clock
+	result, err := newSquirrel(t, squirrel.WithConfig(cfg)).Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Acknowledged) != 1 {
+		t.Errorf("acknowledged = %+v, want /src/app as of %s", result.Acknowledged, now)
+	}
+
+	cfg.AddNote("/src/app", "check the CI", nil)
+	if notes := cfg.NotesFor("/src/app"); len(notes) != 1 || notes[0].CreatedAt.Equal(now) {
+		t.Errorf("notes = %+v, want one stamped by the config's own clock", notes)
+	}
+}
+
+func TestSync(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &squirrel.Config{}
+	if _, err := newSquirrel(t, squirrel.WithConfig(cfg)).Sync(); err == nil {
+		t.Error("no sync directory: no error")
+	}
+
+	cfg.SetSyncDir(dir)
+	cfg.Ack("/src/old", nil)
+	res, err := newSquirrel(t, squirrel.WithConfig(cfg)).Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Exported != 2 || res.Hosts != 0 || filepath.Dir(res.File) != dir {
+		t.Errorf("sync = %+v, want 2 projects exported to %s", res, dir)
+	}
+	if _, err := os.Stat(res.File); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRange(t *testing.T) {
+	r, err := squirrel.ParseRange("2026-03-01", "2026-03-10", 0, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newSquirrel(t, squirrel.WithRange(r))
+	if s.Range() != r {
+		t.Errorf("range = %+v, want %+v", s.Range(), r)
+	}
+	st, err := s.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only /src/old was active, and it is judged as of March 10
+	if st.Prompts != 1 || st.Days != 10 {
+		t.Errorf("stats = %d prompts over %d days, want 1 over 10", st.Prompts, st.Days)
+	}
+}
+
+func TestOptionErrors(t *testing.T) {
+	for name, opt := range map[string]squirrel.Option{
+		"depth": squirrel.WithDepth("thorough"),
+		"days":  squirrel.WithDays(0),
+		"range": squirrel.WithRange(squirrel.Range{Since: now, Until: now.AddDate(0, 0, -1)}),
+	} {
+		if _, err := squirrel.New(opt); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestRenderers(t *testing.T) {
+	st, err := newSquirrel(t).Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	en, err := squirrel.NewRenderer(squirrel.WithColor("never"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	de, err := squirrel.NewRenderer(squirrel.WithLanguage("de_DE.UTF-8"), squirrel.WithColor("never"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Renderers with different languages do not disturb each other
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if out := en.RenderStats(st); !strings.Contains(out, "Statistics") {
+				t.Errorf("english stats:\n%s", out)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if out := de.RenderStats(st); !strings.Contains(out, "Statistik") {
+				t.Errorf("german stats:\n%s", out)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for name, opt := range map[string]squirrel.RenderOption{
+		"language": squirrel.WithLanguage("tlh"),
+		"color":    squirrel.WithColor("sometimes"),
+	} {
+		if _, err := squirrel.NewRenderer(opt); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestConfigSettings(t *testing.T) {
+	cfg := &squirrel.Config{}
+	for key, value := range map[string]string{"defaults.days": "30", "commands.project.days": "90"} {
+		k, err := squirrel.ParseConfigKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.Set(k, value)
+	}
+	if v, _ := cfg.FlagDefault("project", "days"); v != "90" {
+		t.Errorf("project days = %q, want the command's 90", v)
+	}
+	if v, _ := cfg.FlagDefault("status", "days"); v != "30" {
+		t.Errorf("status days = %q, want the default 30", v)
+	}
+	if got := fmt.Sprint(cfg.Settings()); got != "[{defaults.days 30} {commands.project.days 90}]" {
+		t.Errorf("settings = %s", got)
+	}
+	if _, err := squirrel.ParseConfigKey("days"); err == nil {
+		t.Error("bare flag name: no error")
+	}
+}
+
+func TestClaudeDirs(t *testing.T) {
+	dirs := squirrel.ClaudeDirs("/home/a/.claude", "/home/b/.claude", "work=/srv/claude")
+	want := []squirrel.ClaudeDir{{"claude", "/home/a/.claude"}, {"claude2", "/home/b/.claude"}, {"work", "/srv/claude"}}
+	if fmt.Sprint(dirs) != fmt.Sprint(want) {
+		t.Errorf("ClaudeDirs = %v, want %v", dirs, want)
+	}
+}
+
+func ExampleNew() {
+	s, err := squirrel.New(
+		squirrel.WithFS("work", claudeDir()),
+		squirrel.WithClock(func() time.Time { return now }),
+		squirrel.WithGit(git),
+		squirrel.WithDays(30),
+	)
+	if err != nil {
+		panic(err)
+	}
+	result, err := s.Analyze()
+	if err != nil {
+		panic(err)
+	}
+	for _, p := range result.OpenWork {
+		fmt.Printf("%s: %d uncommitted files\n", p.ShortName, p.UncommittedFiles)
+	}
+	// Output: app: 1 uncommitted files
+}
@@ -0,0 +1,493 @@
+package squirrel
+
+import (
+	"time"
+
+	"github.com/dkd-dobberkau/squirrel/internal/analyzer"
+	"github.com/dkd-dobberkau/squirrel/internal/claude"
+	"github.com/dkd-dobberkau/squirrel/internal/config"
+	gitpkg "github.com/dkd-dobberkau/squirrel/internal/git"
+	"github.com/dkd-dobberkau/squirrel/internal/output"
+)
+
+// The types below are this package's own; they are converted from the
+// analysis' internal types, so those can change without breaking users.
+
+// Project is everything known about a project: its activity, sessions,
+// git state and the user's annotations.
+type Project struct {
+	// Path is the canonical path; Aliases are other paths merged into it.
+	Path      string
+	ShortName string
+	Aliases   []string
+	// Host is set for projects seen only on another machine.
+	Host string
+	// Profiles lists the Claude data directories the project was used in,
+	// when several are read.
+	Profiles []string
+
+	Tags  []string
+	Group string
+	// Notes are the user's notes, newest first.
+	Notes       []Note
+	ReminderDue bool
+	// AckedBy is the ack rule that matched, if any.
+	AckedBy   string
+	IgnoredBy string
+	// WokenBy is the wake-up event that ended a snooze.
+	WokenBy string
+
+	// PromptCount counts the prompts in the range.
+	PromptCount   int
+	LastActivity  time.Time
+	FirstActivity time.Time
+	LastPrompt    string
+	// Activity counts prompts per slice of the range, oldest first.
+	Activity []int
+
+	Sessions      []Session
+	LatestSummary string
+	LatestBranch  string
+	// Todos and LastMessages are filled by deep analysis.
+	Todos        []Todo
+	LastMessages []string
+
+	// The git state is filled by medium and deep analysis.
+	GitDirty         bool
+	GitBranch        string
+	UncommittedFiles int
+
+	DaysSinceActive int
+	IsOpenWork      bool
+	Score           float64
+}
+
+// Paths returns the project's canonical path followed by its aliases.
+func (p Project) Paths() []string {
+	return append([]string{p.Path}, p.Aliases...)
+}
+
+// Session is a Claude Code session of a project.
+type Session struct {
+	ID          string
+	File        string
+	ProjectPath string
+	FirstPrompt string
+	Summary     string
+	Messages    int
+	// Created and Modified are RFC 3339 timestamps as recorded by Claude.
+	Created   string
+	Modified  string
+	Branch    string
+	Sidechain bool
+}
+
+// Todo is a TODO, FIXME or open checkbox left in a session (deep analysis
+// only).
+type Todo struct {
+	Text      string
+	Source    string
+	SessionID string
+	// Timestamp is the RFC 3339 time of the message, as recorded by Claude.
+	Timestamp string
+}
+
+// Note is a user-written note on a project.
+type Note struct {
+	Text      string
+	CreatedAt time.Time
+	RemindAt  *time.Time
+}
+
+// HistoryEntry is one prompt from the history.
+type HistoryEntry struct {
+	Display string
+	Project string
+	Time    time.Time
+}
+
+// Result holds the analysed projects by category.
+type Result struct {
+	OpenWork       []Project
+	RecentActivity []Project
+	Sleeping       []Project
+	Acknowledged   []Project
+	// Ignored is only filled with WithIgnored.
+	Ignored []Project
+	// Groups lists the project groups present, in config order.
+	Groups []string
+}
+
+// ProjectDetail is a project with its latest prompts.
+type ProjectDetail struct {
+	Project       Project
+	RecentPrompts []HistoryEntry
+	// Acknowledged is set when an ack or ack rule covers the project.
+	Acknowledged bool
+}
+
+// Range is a half-open time range [Since, Until).
+type Range struct {
+	Since time.Time
+	Until time.Time
+}
+
+// TimelineBucket is one day, week or month of a timeline.
+type TimelineBucket struct {
+	Start time.Time
+	// End is the start of the next bucket.
+	End      time.Time
+	Prompts  int
+	Projects []TimelineProject
+}
+
+// TimelineProject is a project's activity within one bucket.
+type TimelineProject struct {
+	Project     Project
+	Prompts     int
+	FirstPrompt time.Time
+	LastPrompt  time.Time
+	// Sessions last modified within the bucket, newest first.
+	Sessions []Session
+}
+
+// Stats are productivity metrics over the range.
+type Stats struct {
+	Since time.Time
+	Until time.Time
+	// Days is the number of calendar days in the range.
+	Days int
+
+	Prompts        int
+	PromptsPerDay  float64
+	PromptsPerWeek float64
+	ActiveDays     int
+	LongestStreak  Streak
+	// CurrentStreak counts consecutive active days up to Until.
+	CurrentStreak int
+	// Hours counts prompts per hour of the day, local time.
+	Hours [24]int
+	// TopHours are the busiest hours of the day, busiest first.
+	TopHours []int
+
+	Sessions           int
+	AvgSessionMinutes  float64
+	AvgSessionMessages float64
+
+	// ProjectsPerWeek counts distinct projects per week, starting Monday.
+	ProjectsPerWeek    []WeekCount
+	AvgProjectsPerWeek float64
+	// ContextSwitches counts consecutive prompts on the same day that went
+	// to different projects; SwitchRate is their share of all such pairs.
+	ContextSwitches int
+	SwitchRate      float64
+
+	// Sleeping counts projects inactive for more than three days,
+	// SleepingOpen those of them with open work.
+	Sleeping     int
+	SleepingOpen int
+}
+
+// Streak is a run of consecutive active days.
+type Streak struct {
+	Days  int
+	Start time.Time
+	End   time.Time
+}
+
+// WeekCount is a count for the week starting at Start.
+type WeekCount struct {
+	Start time.Time
+	Count int
+}
+
+// AckMatch tells whether and why a project is acknowledged.
+type AckMatch struct {
+	Acknowledged bool
+	// Rule describes the matching ack rule; empty for explicit acks.
+	Rule string
+	// WokenBy names the wake-up event that ended a snooze, if any.
+	WokenBy string
+}
+
+// AckSnapshot is the project state a snooze compares against to wake up.
+type AckSnapshot struct {
+	LastActivity time.Time
+	Dirty        bool
+	// RemoteHeads is a fingerprint of all remote-tracking refs.
+	RemoteHeads string
+	Branches    []string
+}
+
+// GitRunner runs git with args in the directory path and returns its
+// standard output.
+type GitRunner func(path string, args ...string) (string, error)
+
+func fromProject(p claude.ProjectInfo) Project {
+	return Project{
+		Path:             p.Path,
+		ShortName:        p.ShortName,
+		Aliases:          p.Aliases,
+		Host:             p.Host,
+		Profiles:         p.Profiles,
+		Tags:             p.Tags,
+		Group:            p.Group,
+		Notes:            convert(p.Notes, fromNote),
+		ReminderDue:      p.ReminderDue,
+		AckedBy:          p.AckedBy,
+		IgnoredBy:        p.IgnoredBy,
+		WokenBy:          p.WokenBy,
+		PromptCount:      p.PromptCount,
+		LastActivity:     p.LastActivity,
+		FirstActivity:    p.FirstActivity,
+		LastPrompt:       p.LastPrompt,
+		Activity:         p.Activity,
+		Sessions:         convert(p.Sessions, fromSession),
+		LatestSummary:    p.LatestSummary,
+		LatestBranch:     p.LatestBranch,
+		Todos:            convert(p.Todos, fromTodo),
+		LastMessages:     p.LastMessages,
+		GitDirty:         p.GitDirty,
+		GitBranch:        p.GitBranch,
+		UncommittedFiles: p.UncommittedFiles,
+		DaysSinceActive:  p.DaysSinceActive,
+		IsOpenWork:       p.IsOpenWork,
+		Score:            p.Score,
+	}
+}
+
+func toProject(p Project) claude.ProjectInfo {
+	return claude.ProjectInfo{
+		Path:             p.Path,
+		ShortName:        p.ShortName,
+		Aliases:          p.Aliases,
+		Host:             p.Host,
+		Profiles:         p.Profiles,
+		Tags:             p.Tags,
+		Group:            p.Group,
+		Notes:            convert(p.Notes, toNote),
+		ReminderDue:      p.ReminderDue,
+		AckedBy:          p.AckedBy,
+		IgnoredBy:        p.IgnoredBy,
+		WokenBy:          p.WokenBy,
+		PromptCount:      p.PromptCount,
+		LastActivity:     p.LastActivity,
+		FirstActivity:    p.FirstActivity,
+		LastPrompt:       p.LastPrompt,
+		Activity:         p.Activity,
+		Sessions:         convert(p.Sessions, toSession),
+		LatestSummary:    p.LatestSummary,
+		LatestBranch:     p.LatestBranch,
+		Todos:            convert(p.Todos, toTodo),
+		LastMessages:     p.LastMessages,
+		GitDirty:         p.GitDirty,
+		GitBranch:        p.GitBranch,
+		UncommittedFiles: p.UncommittedFiles,
+		DaysSinceActive:  p.DaysSinceActive,
+		IsOpenWork:       p.IsOpenWork,
+		Score:            p.Score,
+	}
+}
+
+func fromSession(s claude.SessionEntry) Session {
+	return Session{
+		ID:          s.SessionID,
+		File:        s.FullPath,
+		ProjectPath: s.ProjectPath,
+		FirstPrompt: s.FirstPrompt,
+		Summary:     s.Summary,
+		Messages:    s.MsgCount,
+		Created:     s.Created,
+		Modified:    s.Modified,
+		Branch:      s.GitBranch,
+		Sidechain:   s.IsSidechain,
+	}
+}
+
+func toSession(s Session) claude.SessionEntry {
+	return claude.SessionEntry{
+		SessionID:   s.ID,
+		FullPath:    s.File,
+		ProjectPath: s.ProjectPath,
+		FirstPrompt: s.FirstPrompt,
+		Summary:     s.Summary,
+		MsgCount:    s.Messages,
+		Created:     s.Created,
+		Modified:    s.Modified,
+		GitBranch:   s.Branch,
+		IsSidechain: s.Sidechain,
+	}
+}
+
+func fromTodo(t claude.TodoItem) Todo {
+	return Todo{Text: t.Text, Source: t.Source, SessionID: t.SessionID, Timestamp: t.Timestamp}
+}
+
+func toTodo(t Todo) claude.TodoItem {
+	return claude.TodoItem{Text: t.Text, Source: t.Source, SessionID: t.SessionID, Timestamp: t.Timestamp}
+}
+
+func fromNote(n claude.Note) Note {
+	return Note{Text: n.Text, CreatedAt: n.CreatedAt, RemindAt: n.RemindAt}
+}
+
+func toNote(n Note) claude.Note {
+	return claude.Note{Text: n.Text, CreatedAt: n.CreatedAt, RemindAt: n.RemindAt}
+}
+
+func fromHistoryEntry(e claude.HistoryEntry) HistoryEntry {
+	return HistoryEntry{Display: e.Display, Project: e.Project, Time: time.UnixMilli(e.Timestamp)}
+}
+
+func toHistoryEntry(e HistoryEntry) claude.HistoryEntry {
+	return claude.HistoryEntry{Display: e.Display, Project: e.Project, Timestamp: e.Time.UnixMilli()}
+}
+
+func fromResult(r analyzer.CategorizedProjects) Result {
+	return Result{
+		OpenWork:       convert(r.OpenWork, fromProject),
+		RecentActivity: convert(r.RecentActivity, fromProject),
+		Sleeping:       convert(r.Sleeping, fromProject),
+		Acknowledged:   convert(r.Acknowledged, fromProject),
+		Ignored:        convert(r.Ignored, fromProject),
+		Groups:         r.Groups,
+	}
+}
+
+func toResult(r Result) analyzer.CategorizedProjects {
+	return analyzer.CategorizedProjects{
+		OpenWork:       convert(r.OpenWork, toProject),
+		RecentActivity: convert(r.RecentActivity, toProject),
+		Sleeping:       convert(r.Sleeping, toProject),
+		Acknowledged:   convert(r.Acknowledged, toProject),
+		Ignored:        convert(r.Ignored, toProject),
+		Groups:         r.Groups,
+	}
+}
+
+func fromProjectDetail(d output.ProjectDetail) ProjectDetail {
+	return ProjectDetail{
+		Project:       fromProject(d.Project),
+		RecentPrompts: convert(d.RecentPrompts, fromHistoryEntry),
+		Acknowledged:  d.Acknowledged,
+	}
+}
+
+func toProjectDetail(d ProjectDetail) output.ProjectDetail {
+	return output.ProjectDetail{
+		Project:       toProject(d.Project),
+		RecentPrompts: convert(d.RecentPrompts, toHistoryEntry),
+		Acknowledged:  d.Acknowledged,
+	}
+}
+
+func fromTimelineBucket(b analyzer.TimelineBucket) TimelineBucket {
+	return TimelineBucket{
+		Start:   b.Start,
+		End:     b.End,
+		Prompts: b.Prompts,
+		Projects: convert(b.Projects, func(p analyzer.TimelineProject) TimelineProject {
+			return TimelineProject{
+				Project:     fromProject(p.Project),
+				Prompts:     p.Prompts,
+				FirstPrompt: p.FirstPrompt,
+				LastPrompt:  p.LastPrompt,
+				Sessions:    convert(p.Sessions, fromSession),
+			}
+		}),
+	}
+}
+
+func toTimelineBucket(b TimelineBucket) analyzer.TimelineBucket {
+	return analyzer.TimelineBucket{
+		Start:   b.Start,
+		End:     b.End,
+		Prompts: b.Prompts,
+		Projects: convert(b.Projects, func(p TimelineProject) analyzer.TimelineProject {
+			return analyzer.TimelineProject{
+				Project:     toProject(p.Project),
+				Prompts:     p.Prompts,
+				FirstPrompt: p.FirstPrompt,
+				LastPrompt:  p.LastPrompt,
+				Sessions:    convert(p.Sessions, toSession),
+			}
+		}),
+	}
+}
+
+func fromStats(s analyzer.Stats) Stats {
+	return Stats{
+		Since:              s.Since,
+		Until:              s.Until,
+		Days:               s.Days,
+		Prompts:            s.Prompts,
+		PromptsPerDay:      s.PromptsPerDay,
+		PromptsPerWeek:     s.PromptsPerWeek,
+		ActiveDays:         s.ActiveDays,
+		LongestStreak:      Streak(s.LongestStreak),
+		CurrentStreak:      s.CurrentStreak,
+		Hours:              s.Hours,
+		TopHours:           s.TopHours,
+		Sessions:           s.Sessions,
+		AvgSessionMinutes:  s.AvgSessionMinutes,
+		AvgSessionMessages: s.AvgSessionMessages,
+		ProjectsPerWeek:    convert(s.ProjectsPerWeek, func(w analyzer.WeekCount) WeekCount { return WeekCount(w) }),
+		AvgProjectsPerWeek: s.AvgProjectsPerWeek,
+		ContextSwitches:    s.ContextSwitches,
+		SwitchRate:         s.SwitchRate,
+		Sleeping:           s.Sleeping,
+		SleepingOpen:       s.SleepingOpen,
+	}
+}
+
+func toStats(s Stats) analyzer.Stats {
+	return analyzer.Stats{
+		Since:              s.Since,
+		Until:              s.Until,
+		Days:               s.Days,
+		Prompts:            s.Prompts,
+		PromptsPerDay:      s.PromptsPerDay,
+		PromptsPerWeek:     s.PromptsPerWeek,
+		ActiveDays:         s.ActiveDays,
+		LongestStreak:      analyzer.Streak(s.LongestStreak),
+		CurrentStreak:      s.CurrentStreak,
+		Hours:              s.Hours,
+		TopHours:           s.TopHours,
+		Sessions:           s.Sessions,
+		AvgSessionMinutes:  s.AvgSessionMinutes,
+		AvgSessionMessages: s.AvgSessionMessages,
+		ProjectsPerWeek:    convert(s.ProjectsPerWeek, func(w WeekCount) analyzer.WeekCount { return analyzer.WeekCount(w) }),
+		AvgProjectsPerWeek: s.AvgProjectsPerWeek,
+		ContextSwitches:    s.ContextSwitches,
+		SwitchRate:         s.SwitchRate,
+		Sleeping:           s.Sleeping,
+		SleepingOpen:       s.SleepingOpen,
+	}
+}
+
+func fromAckSnapshot(s config.AckSnapshot) AckSnapshot {
+	return AckSnapshot(s)
+}
+
+func toAckSnapshot(s AckSnapshot) config.AckSnapshot {
+	return config.AckSnapshot(s)
+}
+
+func toGitRunner(git GitRunner) gitpkg.Runner {
+	if git == nil {
+		return nil
+	}
+	return gitpkg.Runner(git)
+}
+
+// convert maps a slice, keeping nil slices nil.
+func convert[S, T any](in []S, f func(S) T) []T {
+	if in == nil {
+		return nil
+	}
+	out := make([]T, len(in))
+	for i, v := range in {
+		out[i] = f(v)
+	}
+	return out
+}